@@ -10,5 +10,5 @@ import (
 
 func main() {
 	plugin.Serve(&plugin.ServeOpts{
-		ProviderFunc: tekton.Provider})
+		GRPCProviderFunc: tekton.NewProviderServer})
 }
@@ -20,277 +20,417 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"sync"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	tektonapiv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	unstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apimachinery/pkg/runtime/schema"
-	pkgApi "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
 	restclient "k8s.io/client-go/rest"
 )
 
 //go:generate mockgen -source=./client.go -destination=./mock/client_generated.go -package=mock
 
 type Client interface {
-	// Task CRUD operations
-	CreateTask(obj *tektonapiv1.Task) error
-	GetTask(namespace string, name string) (*tektonapiv1.Task, error)
-	UpdateTask(namespace string, name string, obj *tektonapiv1.Task, data []byte) error
-	DeleteTask(namespace string, name string) error
-
-	// TaskRun CRUD operations
-	CreateTaskRun(obj *tektonapiv1.TaskRun) error
-	GetTaskRun(namespace string, name string) (*tektonapiv1.TaskRun, error)
-	UpdateTaskRun(namespace string, name string, obj *tektonapiv1.TaskRun, data []byte) error
-	DeleteTaskRun(namespace string, name string) error
-
-	// Pipeline CRUD operations
-	CreatePipeline(obj *tektonapiv1.Pipeline) error
-	GetPipeline(namespace string, name string) (*tektonapiv1.Pipeline, error)
-	UpdatePipeline(namespace string, name string, obj *tektonapiv1.Pipeline, data []byte) error
-	DeletePipeline(namespace string, name string) error
-
-	// PipelineRun CRUD operations
-	CreatePipelineRun(obj *tektonapiv1.PipelineRun) error
-	GetPipelineRun(namespace string, name string) (*tektonapiv1.PipelineRun, error)
-	UpdatePipelineRun(namespace string, name string, obj *tektonapiv1.PipelineRun, data []byte) error
-	DeletePipelineRun(namespace string, name string) error
+	// Generic CRUD operations, for any registered kind. The objects are given and returned in the
+	// GroupVersion of their kind, and are converted when the cluster serves another version.
+	Create(ctx context.Context, kind *Kind, obj runtime.Object) error
+	Get(ctx context.Context, kind *Kind, namespace string, name string, obj runtime.Object) error
+	Update(ctx context.Context, kind *Kind, namespace string, name string, obj runtime.Object, data []byte) error
+	Delete(ctx context.Context, kind *Kind, namespace string, name string) error
+	List(ctx context.Context, kind *Kind, namespace string, opts ListOptions) (*ObjectList, error)
+	// Watch watches the object name from resourceVersion, or from its current state when
+	// resourceVersion is empty. The events hold objects in the GroupVersion of kind.
+	Watch(ctx context.Context, kind *Kind, namespace string, name string, resourceVersion string) (watch.Interface, error)
+
+	// Tekton configuration
+	GetDefaultsConfig(ctx context.Context) (*config.Defaults, error)
+	// TektonAPIVersion is the group version of the Tekton API used to talk to the cluster, e.g. tekton.dev/v1.
+	TektonAPIVersion() string
+
+	// Pod diagnostics
+	GetPodLogs(ctx context.Context, namespace string, podName string, container string, tailLines int64) (string, error)
+	ListPodEvents(ctx context.Context, namespace string, podName string) ([]corev1.Event, error)
 }
 
+// DefaultFieldManager is the field manager used for server-side apply when none is configured.
+const DefaultFieldManager = "terraform-provider-tekton"
+
+// ServerSideApply makes the client update objects with server-side apply instead of JSON patches,
+// so that the API server tracks the fields managed by Terraform. Objects are still created, under
+// the same field manager, so that an existing object is never silently taken over.
+type ServerSideApply struct {
+	// FieldManager is the name of the manager owning the applied fields.
+	FieldManager string
+	// ForceConflicts takes the ownership of fields conflicting with other managers.
+	ForceConflicts bool
+}
+
+// ConfigFunc returns the configuration to connect to the cluster with.
+type ConfigFunc func() (*restclient.Config, error)
+
 type client struct {
+	config          ConfigFunc
+	serverSideApply *ServerSideApply
+	retryPolicy     *RetryPolicy
+
+	// mu guards the fields below, which are set on the first successful connection.
+	mu            sync.Mutex
 	dynamicClient dynamic.Interface
+	// watchClient is the dynamic client of the watches, which last longer than the request timeout.
+	watchClient dynamic.Interface
+	coreClient  kubernetes.Interface
+	// tektonVersion is the version of the tekton.dev API group served by the cluster.
+	tektonVersion string
 }
 
-// CreatePipeline implements Client
-func (c *client) CreatePipeline(obj *tektonapiv1.Pipeline) error {
-	pipelineUpdateTypeMeta(obj)
-	return c.createResource(obj, obj.Namespace, pipelineRes())
+// New creates our client wrapper object for the actual kubeVirt and kubernetes clients we use.
+// Objects are updated with server-side apply when serverSideApply is set, and the
+// requests failing with a transient error are retried according to retryPolicy when it is set.
+// The clients are only created on first use, with the configuration returned by config, so that
+// the provider can be configured before the cluster it connects to exists.
+func NewClient(config ConfigFunc, serverSideApply *ServerSideApply, retryPolicy *RetryPolicy) Client {
+	return &client{config: config, serverSideApply: serverSideApply, retryPolicy: retryPolicy}
 }
 
-// CreateTaskRun implements Client
-func (c *client) CreateTaskRun(obj *tektonapiv1.TaskRun) error {
-	taskrunUpdateTypeMeta(obj)
-	return c.createResource(obj, obj.Namespace, taskRunRes())
-}
+// connect creates the clients and discovers the Tekton API version, unless it is already done.
+// A failed connection is attempted again on the next call.
+func (c *client) connect() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 
-// DeletePipeline implements Client
-func (c *client) DeletePipeline(namespace string, name string) error {
-	return c.deleteResource(namespace, name, pipelineRes())
-}
+	if c.dynamicClient != nil {
+		return nil
+	}
 
-// DeleteTaskRun implements Client
-func (c *client) DeleteTaskRun(namespace string, name string) error {
-	return c.deleteResource(namespace, name, taskRunRes())
-}
+	cfg, err := c.config()
+	if err != nil {
+		return err
+	}
 
-// GetPipeline implements Client
-func (c *client) GetPipeline(namespace string, name string) (*tektonapiv1.Pipeline, error) {
-	obj, err := c.getResource(namespace, name, pipelineRes())
+	dc, err := dynamic.NewForConfig(cfg)
 	if err != nil {
-		return nil, err
+		msg := fmt.Sprintf("Failed to create client, with error: %v", err)
+		log.Printf("[Error] %s", msg)
+		return fmt.Errorf(msg)
 	}
-	pipeline := &tektonapiv1.Pipeline{}
-	err = runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, pipeline)
+
+	// The timeout of the configuration applies to the whole request, it would end the watches.
+	watchCfg := restclient.CopyConfig(cfg)
+	watchCfg.Timeout = 0
+	wc, err := dynamic.NewForConfig(watchCfg)
 	if err != nil {
-		return nil, err
+		msg := fmt.Sprintf("Failed to create watch client, with error: %v", err)
+		log.Printf("[Error] %s", msg)
+		return fmt.Errorf(msg)
 	}
-	return pipeline, nil
-}
 
-// GetTaskRun implements Client
-func (c *client) GetTaskRun(namespace string, name string) (*tektonapiv1.TaskRun, error) {
-	obj, err := c.getResource(namespace, name, taskRunRes())
+	cs, err := kubernetes.NewForConfig(cfg)
 	if err != nil {
-		return nil, err
+		msg := fmt.Sprintf("Failed to create core client, with error: %v", err)
+		log.Printf("[Error] %s", msg)
+		return fmt.Errorf(msg)
 	}
-	taskrun := &tektonapiv1.TaskRun{}
-	err = runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, taskrun)
+
+	version, err := discoverTektonVersion(cs.Discovery())
 	if err != nil {
-		return nil, err
+		msg := fmt.Sprintf("Failed to discover the Tekton API version, with error: %v", err)
+		log.Printf("[Error] %s", msg)
+		return fmt.Errorf(msg)
 	}
-	return taskrun, nil
+
+	c.dynamicClient = dc
+	c.watchClient = wc
+	c.coreClient = cs
+	c.tektonVersion = version
+	return nil
 }
 
-// UpdatePipeline implements Client
-func (c *client) UpdatePipeline(namespace string, name string, obj *tektonapiv1.Pipeline, data []byte) error {
-	pipelineUpdateTypeMeta(obj)
-	return c.updateResource(namespace, name, pipelineRes(), obj, data)
+// ListOptions selects the objects returned by List.
+type ListOptions struct {
+	// LabelSelector restricts the list to the objects matching the selector, e.g. app=build.
+	LabelSelector string
+	// Limit is the maximum number of objects returned. All the objects are returned, page by page,
+	// when Limit is 0.
+	Limit int64
+	// Continue is the token returned by a previous List, to get the next page.
+	Continue string
 }
 
-// UpdateTaskRun implements Client
-func (c *client) UpdateTaskRun(namespace string, name string, obj *tektonapiv1.TaskRun, data []byte) error {
-	taskrunUpdateTypeMeta(obj)
-	return c.updateResource(namespace, name, taskRunRes(), obj, data)
+// ObjectList is a page of objects returned by List.
+type ObjectList struct {
+	// Items are objects of the listed kind, in its GroupVersion.
+	Items []runtime.Object
+	// Continue is the token to get the next page, empty on the last page.
+	Continue string
 }
 
-// New creates our client wrapper object for the actual kubeVirt and kubernetes clients we use.
-func NewClient(cfg *restclient.Config) (Client, diag.Diagnostics) {
-	var diags diag.Diagnostics
+// listPageSize is the number of objects requested at once when all the objects are listed.
+const listPageSize = 500
 
-	result := &client{}
-	c, err := dynamic.NewForConfig(cfg)
+// Generic CRUD operations
+
+// Create implements Client
+func (c *client) Create(ctx context.Context, kind *Kind, obj runtime.Object) error {
+	if err := c.connect(); err != nil {
+		return err
+	}
+	accessor, err := meta.Accessor(obj)
 	if err != nil {
-		msg := fmt.Sprintf("Failed to create client, with error: %v", err)
-		log.Printf("[Error] %s", msg)
-		return nil, diag.FromErr(fmt.Errorf(msg))
+		return err
+	}
+	served, err := c.toServedVersion(ctx, kind, obj)
+	if err != nil {
+		return err
+	}
+	if err := c.createResource(ctx, served, accessor.GetNamespace(), kind); err != nil {
+		return err
 	}
-	result.dynamicClient = c
-	return result, diags
+	return c.fromServedVersion(ctx, kind, served, obj)
 }
 
-func taskrunUpdateTypeMeta(obj *tektonapiv1.TaskRun) {
-	obj.APIVersion = "tekton.dev/v1alpha1"
-	obj.Kind = "TaskRun"
+// Get implements Client
+func (c *client) Get(ctx context.Context, kind *Kind, namespace string, name string, obj runtime.Object) error {
+	if err := c.connect(); err != nil {
+		return err
+	}
+	var resp *unstructured.Unstructured
+	err := c.retry(ctx, fmt.Sprintf("get %s %s", kind, name), isTransientError, func() (err error) {
+		resp, err = c.resourceInterface(kind, namespace).Get(ctx, name, metav1.GetOptions{})
+		return err
+	})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			log.Printf("[Warning] %s %s not found (namespace=%s)", kind, name, namespace)
+			return err
+		}
+		msg := fmt.Sprintf("Failed to get %s, with error: %v", kind, err)
+		log.Printf("[Error] %s", msg)
+		return fmt.Errorf(msg)
+	}
+	return c.fromUnstructured(ctx, kind, resp.UnstructuredContent(), obj)
 }
 
-func taskRunRes() schema.GroupVersionResource {
-	return schema.GroupVersionResource{
-		Group:    "tekton.dev",
-		Version:  "v1alpha1",
-		Resource: "taskruns",
+// Update patches the object with the JSON patch data, computed against its representation in the
+// GroupVersion of kind, or applies obj when server-side apply is enabled.
+func (c *client) Update(ctx context.Context, kind *Kind, namespace string, name string, obj runtime.Object, data []byte) error {
+	if err := c.connect(); err != nil {
+		return err
+	}
+	if c.serverSideApply == nil {
+		return c.patchResource(ctx, kind, namespace, name, obj, data)
 	}
-}
 
-func pipelineUpdateTypeMeta(obj *tektonapiv1.Pipeline) {
-	obj.APIVersion = "tekton.dev/v1alpha1"
-	obj.Kind = "Pipeline"
+	served, err := c.toServedVersion(ctx, kind, obj)
+	if err != nil {
+		return err
+	}
+	if err := c.applyResource(ctx, namespace, name, kind, served); err != nil {
+		return err
+	}
+	return c.fromServedVersion(ctx, kind, served, obj)
 }
 
-func pipelineRes() schema.GroupVersionResource {
-	return schema.GroupVersionResource{
-		Group:    "tekton.dev",
-		Version:  "v1alpha1",
-		Resource: "pipelines",
+// Delete implements Client
+func (c *client) Delete(ctx context.Context, kind *Kind, namespace string, name string) error {
+	if err := c.connect(); err != nil {
+		return err
 	}
+	// A delete failing with a server or network error may have been processed anyway, the object is
+	// then not found when the delete is retried.
+	attempt := 0
+	return c.retry(ctx, fmt.Sprintf("delete %s %s", kind, name), isTransientError, func() error {
+		attempt++
+		err := c.resourceInterface(kind, namespace).Delete(ctx, name, metav1.DeleteOptions{})
+		if attempt > 1 && errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	})
 }
 
-// PipelineRun CRUD operations
-
-// CreatePipelineRun implements Client
-func (c *client) CreatePipelineRun(obj *tektonapiv1.PipelineRun) error {
-	pipelineRunUpdateTypeMeta(obj)
-	return c.createResource(obj, obj.Namespace, pipelineRunRes())
-}
+// List implements Client
+func (c *client) List(ctx context.Context, kind *Kind, namespace string, opts ListOptions) (*ObjectList, error) {
+	if err := c.connect(); err != nil {
+		return nil, err
+	}
+	listOptions := metav1.ListOptions{
+		LabelSelector: opts.LabelSelector,
+		Limit:         opts.Limit,
+		Continue:      opts.Continue,
+	}
+	if opts.Limit == 0 {
+		listOptions.Limit = listPageSize
+	}
 
-// DeletePipelineRun implements Client
-func (c *client) DeletePipelineRun(namespace string, name string) error {
-	return c.deleteResource(namespace, name, pipelineRunRes())
+	result := &ObjectList{}
+	for {
+		var resp *unstructured.UnstructuredList
+		err := c.retry(ctx, fmt.Sprintf("list %s", kind), isTransientError, func() (err error) {
+			resp, err = c.resourceInterface(kind, namespace).List(ctx, listOptions)
+			return err
+		})
+		if err != nil {
+			msg := fmt.Sprintf("Failed to list %s, with error: %v", kind, err)
+			log.Printf("[Error] %s", msg)
+			return nil, fmt.Errorf(msg)
+		}
+		for i := range resp.Items {
+			obj := kind.New()
+			if err := c.fromUnstructured(ctx, kind, resp.Items[i].UnstructuredContent(), obj); err != nil {
+				return nil, err
+			}
+			result.Items = append(result.Items, obj)
+		}
+		result.Continue = resp.GetContinue()
+		if opts.Limit > 0 || result.Continue == "" {
+			return result, nil
+		}
+		listOptions.Continue = result.Continue
+	}
 }
 
-// GetPipelineRun implements Client
-func (c *client) GetPipelineRun(namespace string, name string) (*tektonapiv1.PipelineRun, error) {
-	var obj tektonapiv1.PipelineRun
-	resp, err := c.getResource(namespace, name, pipelineRunRes())
-	if err != nil {
-		if errors.IsNotFound(err) {
-			log.Printf("[Warning] PipelineRun %s not found (namespace=%s)", name, namespace)
-			return nil, err
-		}
-		msg := fmt.Sprintf("Failed to get pipelinerun, with error: %v", err)
-		log.Printf("[Error] %s", msg)
-		return nil, fmt.Errorf(msg)
+// Watch implements Client
+func (c *client) Watch(ctx context.Context, kind *Kind, namespace string, name string, resourceVersion string) (watch.Interface, error) {
+	if err := c.connect(); err != nil {
+		return nil, err
 	}
-	unstructured := resp.UnstructuredContent()
-	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(unstructured, &obj); err != nil {
-		msg := fmt.Sprintf("Failed to translate unstructed to PipelineRun, with error: %v", err)
+	w, err := c.watchInterface(kind, namespace).Watch(ctx, metav1.ListOptions{
+		FieldSelector:   fields.OneTermEqualSelector("metadata.name", name).String(),
+		ResourceVersion: resourceVersion,
+	})
+	if err != nil {
+		msg := fmt.Sprintf("Failed to watch %s %s, with error: %v", kind, name, err)
 		log.Printf("[Error] %s", msg)
 		return nil, fmt.Errorf(msg)
 	}
-	return &obj, nil
-}
 
-// UpdatePipelineRun implements Client
-func (c *client) UpdatePipelineRun(namespace string, name string, obj *tektonapiv1.PipelineRun, data []byte) error {
-	pipelineRunUpdateTypeMeta(obj)
-	return c.updateResource(namespace, name, pipelineRunRes(), obj, data)
-}
-
-func pipelineRunRes() schema.GroupVersionResource {
-	return schema.GroupVersionResource{
-		Group:    "tekton.dev",
-		Version:  "v1alpha1",
-		Resource: "pipelineruns",
-	}
-}
-
-func pipelineRunUpdateTypeMeta(obj *tektonapiv1.PipelineRun) {
-	obj.APIVersion = "tekton.dev/v1alpha1"
-	obj.Kind = "PipelineRun"
+	return watch.Filter(w, func(in watch.Event) (watch.Event, bool) {
+		u, ok := in.Object.(*unstructured.Unstructured)
+		if !ok || in.Type == watch.Error {
+			return in, true
+		}
+		obj := kind.New()
+		if err := c.fromUnstructured(ctx, kind, u.UnstructuredContent(), obj); err != nil {
+			return watch.Event{Type: watch.Error, Object: &errors.NewInternalError(err).ErrStatus}, true
+		}
+		return watch.Event{Type: in.Type, Object: obj}, true
+	}), nil
 }
 
-// Pipeline CRUD operations
+// Tekton configuration
 
-// Task CRUD operations
+// tektonNamespace is the namespace the Tekton controllers and their configuration live in.
+const tektonNamespace = "tekton-pipelines"
 
-func (c *client) CreateTask(obj *tektonapiv1.Task) error {
-	taskUpdateTypeMeta(obj)
-	return c.createResource(obj, obj.Namespace, taskRes())
+// GetDefaultsConfig reads the config-defaults ConfigMap of the Tekton installation.
+func (c *client) GetDefaultsConfig(ctx context.Context) (*config.Defaults, error) {
+	cm := &corev1.ConfigMap{}
+	if err := c.Get(ctx, ConfigMapKind, tektonNamespace, config.GetDefaultsConfigName(), cm); err != nil {
+		msg := fmt.Sprintf("Failed to get ConfigMap %s/%s, with error: %v", tektonNamespace, config.GetDefaultsConfigName(), err)
+		log.Printf("[Warning] %s", msg)
+		return nil, fmt.Errorf(msg)
+	}
+	return config.NewDefaultsFromConfigMap(cm)
 }
 
-func (c *client) GetTask(namespace string, name string) (*tektonapiv1.Task, error) {
-	var obj tektonapiv1.Task
-	resp, err := c.getResource(namespace, name, taskRes())
+// Pod diagnostics
+
+// GetPodLogs returns the last tailLines lines of the logs of a container of a pod.
+func (c *client) GetPodLogs(ctx context.Context, namespace string, podName string, container string, tailLines int64) (string, error) {
+	if err := c.connect(); err != nil {
+		return "", err
+	}
+	var data []byte
+	err := c.retry(ctx, fmt.Sprintf("get the logs of pod %s/%s", namespace, podName), isTransientError, func() (err error) {
+		data, err = c.coreClient.CoreV1().Pods(namespace).GetLogs(podName, &corev1.PodLogOptions{
+			Container: container,
+			TailLines: &tailLines,
+		}).DoRaw(ctx)
+		return err
+	})
 	if err != nil {
-		if errors.IsNotFound(err) {
-			log.Printf("[Warning] Task %s not found (namespace=%s)", name, namespace)
-			return nil, err
-		}
-		msg := fmt.Sprintf("Failed to get Task, with error: %v", err)
+		msg := fmt.Sprintf("Failed to get logs of container %s of pod %s/%s, with error: %v", container, namespace, podName, err)
 		log.Printf("[Error] %s", msg)
-		return nil, fmt.Errorf(msg)
+		return "", fmt.Errorf(msg)
 	}
-	unstructured := resp.UnstructuredContent()
-	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(unstructured, &obj); err != nil {
-		msg := fmt.Sprintf("Failed to translate unstructed to Task, with error: %v", err)
+	return string(data), nil
+}
+
+// ListPodEvents returns the events involving a pod.
+func (c *client) ListPodEvents(ctx context.Context, namespace string, podName string) ([]corev1.Event, error) {
+	if err := c.connect(); err != nil {
+		return nil, err
+	}
+	selector := fields.Set{
+		"involvedObject.kind": "Pod",
+		"involvedObject.name": podName,
+	}.AsSelector().String()
+	var events *corev1.EventList
+	err := c.retry(ctx, fmt.Sprintf("list the events of pod %s/%s", namespace, podName), isTransientError, func() (err error) {
+		events, err = c.coreClient.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{FieldSelector: selector})
+		return err
+	})
+	if err != nil {
+		msg := fmt.Sprintf("Failed to list events of pod %s/%s, with error: %v", namespace, podName, err)
 		log.Printf("[Error] %s", msg)
 		return nil, fmt.Errorf(msg)
 	}
-	return &obj, nil
+	return events.Items, nil
 }
 
-func (c *client) UpdateTask(namespace string, name string, obj *tektonapiv1.Task, data []byte) error {
-	taskUpdateTypeMeta(obj)
-	return c.updateResource(namespace, name, taskRes(), obj, data)
-}
-
-func (c *client) DeleteTask(namespace string, name string) error {
-	return c.deleteResource(namespace, name, taskRes())
-}
+// Generic Resource CRUD operations
 
-func taskUpdateTypeMeta(obj *tektonapiv1.Task) {
-	obj.TypeMeta = metav1.TypeMeta{
-		Kind:       "Task",
-		APIVersion: tektonapiv1.SchemeGroupVersion.String(),
+// resourceInterface returns the dynamic client of kind, in namespace when the kind is namespaced.
+func (c *client) resourceInterface(kind *Kind, namespace string) dynamic.ResourceInterface {
+	resource := c.dynamicClient.Resource(c.resource(kind))
+	if !kind.Namespaced {
+		return resource
 	}
+	return resource.Namespace(namespace)
 }
 
-func taskRes() schema.GroupVersionResource {
-	return schema.GroupVersionResource{
-		Group:    tektonapiv1.SchemeGroupVersion.Group,
-		Version:  tektonapiv1.SchemeGroupVersion.Version,
-		Resource: "task",
+// watchInterface returns the dynamic client of kind to watch with, in namespace when the kind is
+// namespaced. Unlike resourceInterface, its requests don't time out.
+func (c *client) watchInterface(kind *Kind, namespace string) dynamic.ResourceInterface {
+	resource := c.watchClient.Resource(c.resource(kind))
+	if !kind.Namespaced {
+		return resource
 	}
-
+	return resource.Namespace(namespace)
 }
 
-// Generic Resource CRUD operations
-
-func (c *client) createResource(obj interface{}, namespace string, resource schema.GroupVersionResource) error {
+func (c *client) createResource(ctx context.Context, obj interface{}, namespace string, kind *Kind) error {
 	resultMap, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
 	if err != nil {
-		msg := fmt.Sprintf("Failed to translate %s to Unstructed (for create operation), with error: %v", resource.Resource, err)
+		msg := fmt.Sprintf("Failed to translate %s to Unstructed (for create operation), with error: %v", kind, err)
 		log.Printf("[Error] %s", msg)
 		return fmt.Errorf(msg)
 	}
 	input := unstructured.Unstructured{}
 	input.SetUnstructuredContent(resultMap)
-	resp, err := c.dynamicClient.Resource(resource).Namespace(namespace).Create(context.Background(), &input, metav1.CreateOptions{})
+	// Objects are always created, applying them would take over an existing object of the same name.
+	// With server-side apply, the fields set on create are owned by the same manager as the applied ones.
+	options := metav1.CreateOptions{}
+	if c.serverSideApply != nil {
+		options.FieldManager = c.serverSideApply.FieldManager
+	}
+	// A create failing with a server or network error may have been processed anyway, retrying it
+	// could create the object twice when it has a generated name. Only throttled creates are retried.
+	var resp *unstructured.Unstructured
+	err = c.retry(ctx, fmt.Sprintf("create %s", kind), isThrottledError, func() (err error) {
+		resp, err = c.resourceInterface(kind, namespace).Create(ctx, &input, options)
+		return err
+	})
 	if err != nil {
-		msg := fmt.Sprintf("Failed to create %s, with error: %v", resource.Resource, err)
+		msg := fmt.Sprintf("Failed to create %s, with error: %v", kind, err)
 		log.Printf("[Error] %s", msg)
 		return fmt.Errorf(msg)
 	}
@@ -298,27 +438,37 @@ func (c *client) createResource(obj interface{}, namespace string, resource sche
 	return runtime.DefaultUnstructuredConverter.FromUnstructured(unstructured, obj)
 }
 
-func (c *client) getResource(namespace string, name string, resource schema.GroupVersionResource) (*unstructured.Unstructured, error) {
-	return c.dynamicClient.Resource(resource).Namespace(namespace).Get(context.Background(), name, metav1.GetOptions{})
-}
-
-func (c *client) updateResource(namespace string, name string, resource schema.GroupVersionResource, obj interface{}, data []byte) error {
-	// patch, merge
-	resp, err := c.dynamicClient.Resource(resource).Namespace(namespace).Patch(
-		context.Background(),
-		name,
-		pkgApi.JSONPatchType,
-		data,
-		metav1.PatchOptions{})
+func (c *client) applyResource(ctx context.Context, namespace string, name string, kind *Kind, obj interface{}) error {
+	resultMap, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
 	if err != nil {
-		msg := fmt.Sprintf("Failed to update %s, with error: %v", resource.Resource, err)
+		msg := fmt.Sprintf("Failed to translate %s to Unstructed (for apply operation), with error: %v", kind, err)
+		log.Printf("[Error] %s", msg)
+		return fmt.Errorf(msg)
+	}
+	input := unstructured.Unstructured{}
+	input.SetUnstructuredContent(resultMap)
+	// Leave the fields set by the API server and the Tekton controllers out of the applied configuration.
+	unstructured.RemoveNestedField(input.Object, "metadata", "creationTimestamp")
+	unstructured.RemoveNestedField(input.Object, "status")
+
+	// The conflicts of server-side apply are between field managers, they are not retried.
+	var resp *unstructured.Unstructured
+	err = c.retry(ctx, fmt.Sprintf("apply %s %s", kind, name), isTransientError, func() (err error) {
+		resp, err = c.resourceInterface(kind, namespace).Apply(
+			ctx,
+			name,
+			&input,
+			metav1.ApplyOptions{
+				FieldManager: c.serverSideApply.FieldManager,
+				Force:        c.serverSideApply.ForceConflicts,
+			})
+		return err
+	})
+	if err != nil {
+		msg := fmt.Sprintf("Failed to apply %s, with error: %v", kind, err)
 		log.Printf("[Error] %s", msg)
 		return fmt.Errorf(msg)
 	}
 	unstructured := resp.UnstructuredContent()
 	return runtime.DefaultUnstructuredConverter.FromUnstructured(unstructured, obj)
 }
-
-func (c *client) deleteResource(namespace string, name string, resource schema.GroupVersionResource) error {
-	return c.dynamicClient.Resource(resource).Namespace(namespace).Delete(context.Background(), name, metav1.DeleteOptions{})
-}
@@ -0,0 +1,69 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestDelete(t *testing.T) {
+	testCases := []struct {
+		// Exists is true when the task exists before it is deleted.
+		Exists bool
+		// LostResponses is the number of deletes failing with a 503, the first one being processed.
+		LostResponses    int
+		ExpectedDeletes  int
+		ExpectedNotFound bool
+	}{
+		{Exists: true, ExpectedDeletes: 1},
+		{Exists: true, LostResponses: 1, ExpectedDeletes: 2},
+		{Exists: true, LostResponses: 2, ExpectedDeletes: 3},
+		{Exists: false, ExpectedDeletes: 1, ExpectedNotFound: true},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			var objects []runtime.Object
+			if tc.Exists {
+				objects = append(objects, newTestTask("v1", "1"))
+			}
+			dc := newTestDynamicClient(objects...)
+
+			deletes := 0
+			dc.PrependReactor("delete", "tasks", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				deletes++
+				if deletes > tc.LostResponses {
+					return false, nil, nil
+				}
+				if deletes == 1 {
+					if err := dc.Tracker().Delete(action.GetResource(), "default", "build"); err != nil {
+						return true, nil, err
+					}
+				}
+				return true, nil, errors.NewServiceUnavailable("unavailable")
+			})
+
+			c := &client{
+				dynamicClient: dc,
+				tektonVersion: "v1",
+				retryPolicy:   &RetryPolicy{MaxAttempts: 5, Backoff: time.Millisecond, MaxBackoff: time.Millisecond},
+			}
+			err := c.Delete(context.Background(), TaskKind, "default", "build")
+			if tc.ExpectedNotFound {
+				if !errors.IsNotFound(err) {
+					t.Fatalf("Expected a not found error, given: %v", err)
+				}
+			} else if err != nil {
+				t.Fatal(err)
+			}
+			if deletes != tc.ExpectedDeletes {
+				t.Fatalf("Expected %d deletes, given: %d", tc.ExpectedDeletes, deletes)
+			}
+		})
+	}
+}
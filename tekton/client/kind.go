@@ -0,0 +1,134 @@
+package client
+
+import (
+	"fmt"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	tektonapiv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	tektonapiv1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/apis"
+)
+
+// ConvertibleObject is an object which can be converted to and from the other versions of its kind.
+type ConvertibleObject interface {
+	runtime.Object
+	apis.Convertible
+}
+
+// Kind describes a kind of object the client can manage.
+type Kind struct {
+	// Kind is the name of the kind, e.g. Task.
+	Kind string
+	// GroupVersion is the group and version of the objects exchanged with the client.
+	GroupVersion schema.GroupVersion
+	// Resource is the name of the kind in the REST API, e.g. tasks.
+	Resource string
+	// Namespaced is true when the objects of the kind live in a namespace.
+	Namespaced bool
+	// New returns an empty object of the kind, in GroupVersion.
+	New func() runtime.Object
+	// Conversions returns empty objects of the other versions of the kind, keyed by version. The
+	// objects are converted from and to one of these versions when the cluster doesn't serve
+	// GroupVersion. The objects returned by New must be a ConvertibleObject.
+	Conversions map[string]func() ConvertibleObject
+}
+
+// GroupVersionKind returns the group, version and kind of the objects exchanged with the client.
+func (k *Kind) GroupVersionKind() schema.GroupVersionKind {
+	return k.GroupVersion.WithKind(k.Kind)
+}
+
+func (k *Kind) String() string {
+	return k.Kind
+}
+
+// kinds is the registry of the kinds the client can manage, keyed by group, version and kind.
+var kinds = map[schema.GroupVersionKind]*Kind{}
+
+// RegisterKind adds k to the kinds the client can manage and returns it.
+func RegisterKind(k *Kind) *Kind {
+	gvk := k.GroupVersionKind()
+	if _, ok := kinds[gvk]; ok {
+		panic(fmt.Sprintf("kind %s is already registered", gvk))
+	}
+	kinds[gvk] = k
+	return k
+}
+
+// KindFor returns the registered kind of gvk.
+func KindFor(gvk schema.GroupVersionKind) (*Kind, bool) {
+	k, ok := kinds[gvk]
+	return k, ok
+}
+
+// Tekton kinds
+var (
+	TaskKind = RegisterKind(&Kind{
+		Kind:         "Task",
+		GroupVersion: tektonapiv1.SchemeGroupVersion,
+		Resource:     "tasks",
+		Namespaced:   true,
+		New:          func() runtime.Object { return &tektonapiv1.Task{} },
+		Conversions: map[string]func() ConvertibleObject{
+			tektonapiv1beta1.SchemeGroupVersion.Version: func() ConvertibleObject { return &tektonapiv1beta1.Task{} },
+		},
+	})
+	TaskRunKind = RegisterKind(&Kind{
+		Kind:         "TaskRun",
+		GroupVersion: tektonapiv1.SchemeGroupVersion,
+		Resource:     "taskruns",
+		Namespaced:   true,
+		New:          func() runtime.Object { return &tektonapiv1.TaskRun{} },
+		Conversions: map[string]func() ConvertibleObject{
+			tektonapiv1beta1.SchemeGroupVersion.Version: func() ConvertibleObject { return &tektonapiv1beta1.TaskRun{} },
+		},
+	})
+	PipelineKind = RegisterKind(&Kind{
+		Kind:         "Pipeline",
+		GroupVersion: tektonapiv1.SchemeGroupVersion,
+		Resource:     "pipelines",
+		Namespaced:   true,
+		New:          func() runtime.Object { return &tektonapiv1.Pipeline{} },
+		Conversions: map[string]func() ConvertibleObject{
+			tektonapiv1beta1.SchemeGroupVersion.Version: func() ConvertibleObject { return &tektonapiv1beta1.Pipeline{} },
+		},
+	})
+	PipelineRunKind = RegisterKind(&Kind{
+		Kind:         "PipelineRun",
+		GroupVersion: tektonapiv1.SchemeGroupVersion,
+		Resource:     "pipelineruns",
+		Namespaced:   true,
+		New:          func() runtime.Object { return &tektonapiv1.PipelineRun{} },
+		Conversions: map[string]func() ConvertibleObject{
+			tektonapiv1beta1.SchemeGroupVersion.Version: func() ConvertibleObject { return &tektonapiv1beta1.PipelineRun{} },
+		},
+	})
+	// CustomRunKind is only served as v1beta1.
+	CustomRunKind = RegisterKind(&Kind{
+		Kind:         "CustomRun",
+		GroupVersion: tektonapiv1beta1.SchemeGroupVersion,
+		Resource:     "customruns",
+		Namespaced:   true,
+		New:          func() runtime.Object { return &tektonapiv1beta1.CustomRun{} },
+	})
+)
+
+// Kubernetes kinds
+var (
+	ConfigMapKind = RegisterKind(&Kind{
+		Kind:         "ConfigMap",
+		GroupVersion: corev1.SchemeGroupVersion,
+		Resource:     "configmaps",
+		Namespaced:   true,
+		New:          func() runtime.Object { return &corev1.ConfigMap{} },
+	})
+)
+
+// isTektonPipelineKind reports whether k is one of the kinds the cluster must serve in the
+// discovered Tekton API version.
+func isTektonPipelineKind(k *Kind) bool {
+	return k.GroupVersion.Group == pipeline.GroupName && len(k.Conversions) > 0
+}
@@ -5,10 +5,15 @@
 package mock
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
-	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	client "github.com/rh01/terraform-provider-tekton/tekton/client"
+	config "github.com/tektoncd/pipeline/pkg/apis/config"
+	v1 "k8s.io/api/core/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
 )
 
 // MockClient is a mock of Client interface.
@@ -34,230 +39,147 @@ func (m *MockClient) EXPECT() *MockClientMockRecorder {
 	return m.recorder
 }
 
-// CreatePipeline mocks base method.
-func (m *MockClient) CreatePipeline(obj *v1.Pipeline) error {
+// Create mocks base method.
+func (m *MockClient) Create(ctx context.Context, kind *client.Kind, obj runtime.Object) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreatePipeline", obj)
+	ret := m.ctrl.Call(m, "Create", ctx, kind, obj)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// CreatePipeline indicates an expected call of CreatePipeline.
-func (mr *MockClientMockRecorder) CreatePipeline(obj interface{}) *gomock.Call {
+// Create indicates an expected call of Create.
+func (mr *MockClientMockRecorder) Create(ctx, kind, obj interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePipeline", reflect.TypeOf((*MockClient)(nil).CreatePipeline), obj)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockClient)(nil).Create), ctx, kind, obj)
 }
 
-// CreatePipelineRun mocks base method.
-func (m *MockClient) CreatePipelineRun(obj *v1.PipelineRun) error {
+// Delete mocks base method.
+func (m *MockClient) Delete(ctx context.Context, kind *client.Kind, namespace, name string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreatePipelineRun", obj)
+	ret := m.ctrl.Call(m, "Delete", ctx, kind, namespace, name)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// CreatePipelineRun indicates an expected call of CreatePipelineRun.
-func (mr *MockClientMockRecorder) CreatePipelineRun(obj interface{}) *gomock.Call {
+// Delete indicates an expected call of Delete.
+func (mr *MockClientMockRecorder) Delete(ctx, kind, namespace, name interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePipelineRun", reflect.TypeOf((*MockClient)(nil).CreatePipelineRun), obj)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockClient)(nil).Delete), ctx, kind, namespace, name)
 }
 
-// CreateTask mocks base method.
-func (m *MockClient) CreateTask(obj *v1.Task) error {
+// Get mocks base method.
+func (m *MockClient) Get(ctx context.Context, kind *client.Kind, namespace, name string, obj runtime.Object) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateTask", obj)
+	ret := m.ctrl.Call(m, "Get", ctx, kind, namespace, name, obj)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// CreateTask indicates an expected call of CreateTask.
-func (mr *MockClientMockRecorder) CreateTask(obj interface{}) *gomock.Call {
+// Get indicates an expected call of Get.
+func (mr *MockClientMockRecorder) Get(ctx, kind, namespace, name, obj interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTask", reflect.TypeOf((*MockClient)(nil).CreateTask), obj)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockClient)(nil).Get), ctx, kind, namespace, name, obj)
 }
 
-// CreateTaskRun mocks base method.
-func (m *MockClient) CreateTaskRun(obj *v1.TaskRun) error {
+// GetDefaultsConfig mocks base method.
+func (m *MockClient) GetDefaultsConfig(ctx context.Context) (*config.Defaults, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateTaskRun", obj)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// CreateTaskRun indicates an expected call of CreateTaskRun.
-func (mr *MockClientMockRecorder) CreateTaskRun(obj interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTaskRun", reflect.TypeOf((*MockClient)(nil).CreateTaskRun), obj)
-}
-
-// DeletePipeline mocks base method.
-func (m *MockClient) DeletePipeline(namespace, name string) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeletePipeline", namespace, name)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// DeletePipeline indicates an expected call of DeletePipeline.
-func (mr *MockClientMockRecorder) DeletePipeline(namespace, name interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePipeline", reflect.TypeOf((*MockClient)(nil).DeletePipeline), namespace, name)
-}
-
-// DeletePipelineRun mocks base method.
-func (m *MockClient) DeletePipelineRun(namespace, name string) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeletePipelineRun", namespace, name)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// DeletePipelineRun indicates an expected call of DeletePipelineRun.
-func (mr *MockClientMockRecorder) DeletePipelineRun(namespace, name interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePipelineRun", reflect.TypeOf((*MockClient)(nil).DeletePipelineRun), namespace, name)
-}
-
-// DeleteTask mocks base method.
-func (m *MockClient) DeleteTask(namespace, name string) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteTask", namespace, name)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// DeleteTask indicates an expected call of DeleteTask.
-func (mr *MockClientMockRecorder) DeleteTask(namespace, name interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockClient)(nil).DeleteTask), namespace, name)
-}
-
-// DeleteTaskRun mocks base method.
-func (m *MockClient) DeleteTaskRun(namespace, name string) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteTaskRun", namespace, name)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// DeleteTaskRun indicates an expected call of DeleteTaskRun.
-func (mr *MockClientMockRecorder) DeleteTaskRun(namespace, name interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTaskRun", reflect.TypeOf((*MockClient)(nil).DeleteTaskRun), namespace, name)
-}
-
-// GetPipeline mocks base method.
-func (m *MockClient) GetPipeline(namespace, name string) (*v1.Pipeline, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPipeline", namespace, name)
-	ret0, _ := ret[0].(*v1.Pipeline)
+	ret := m.ctrl.Call(m, "GetDefaultsConfig", ctx)
+	ret0, _ := ret[0].(*config.Defaults)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetPipeline indicates an expected call of GetPipeline.
-func (mr *MockClientMockRecorder) GetPipeline(namespace, name interface{}) *gomock.Call {
+// GetDefaultsConfig indicates an expected call of GetDefaultsConfig.
+func (mr *MockClientMockRecorder) GetDefaultsConfig(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPipeline", reflect.TypeOf((*MockClient)(nil).GetPipeline), namespace, name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDefaultsConfig", reflect.TypeOf((*MockClient)(nil).GetDefaultsConfig), ctx)
 }
 
-// GetPipelineRun mocks base method.
-func (m *MockClient) GetPipelineRun(namespace, name string) (*v1.PipelineRun, error) {
+// GetPodLogs mocks base method.
+func (m *MockClient) GetPodLogs(ctx context.Context, namespace, podName, container string, tailLines int64) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPipelineRun", namespace, name)
-	ret0, _ := ret[0].(*v1.PipelineRun)
+	ret := m.ctrl.Call(m, "GetPodLogs", ctx, namespace, podName, container, tailLines)
+	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetPipelineRun indicates an expected call of GetPipelineRun.
-func (mr *MockClientMockRecorder) GetPipelineRun(namespace, name interface{}) *gomock.Call {
+// GetPodLogs indicates an expected call of GetPodLogs.
+func (mr *MockClientMockRecorder) GetPodLogs(ctx, namespace, podName, container, tailLines interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPipelineRun", reflect.TypeOf((*MockClient)(nil).GetPipelineRun), namespace, name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPodLogs", reflect.TypeOf((*MockClient)(nil).GetPodLogs), ctx, namespace, podName, container, tailLines)
 }
 
-// GetTask mocks base method.
-func (m *MockClient) GetTask(namespace, name string) (*v1.Task, error) {
+// List mocks base method.
+func (m *MockClient) List(ctx context.Context, kind *client.Kind, namespace string, opts client.ListOptions) (*client.ObjectList, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTask", namespace, name)
-	ret0, _ := ret[0].(*v1.Task)
+	ret := m.ctrl.Call(m, "List", ctx, kind, namespace, opts)
+	ret0, _ := ret[0].(*client.ObjectList)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetTask indicates an expected call of GetTask.
-func (mr *MockClientMockRecorder) GetTask(namespace, name interface{}) *gomock.Call {
+// List indicates an expected call of List.
+func (mr *MockClientMockRecorder) List(ctx, kind, namespace, opts interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTask", reflect.TypeOf((*MockClient)(nil).GetTask), namespace, name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockClient)(nil).List), ctx, kind, namespace, opts)
 }
 
-// GetTaskRun mocks base method.
-func (m *MockClient) GetTaskRun(namespace, name string) (*v1.TaskRun, error) {
+// ListPodEvents mocks base method.
+func (m *MockClient) ListPodEvents(ctx context.Context, namespace, podName string) ([]v1.Event, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTaskRun", namespace, name)
-	ret0, _ := ret[0].(*v1.TaskRun)
+	ret := m.ctrl.Call(m, "ListPodEvents", ctx, namespace, podName)
+	ret0, _ := ret[0].([]v1.Event)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetTaskRun indicates an expected call of GetTaskRun.
-func (mr *MockClientMockRecorder) GetTaskRun(namespace, name interface{}) *gomock.Call {
+// ListPodEvents indicates an expected call of ListPodEvents.
+func (mr *MockClientMockRecorder) ListPodEvents(ctx, namespace, podName interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskRun", reflect.TypeOf((*MockClient)(nil).GetTaskRun), namespace, name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPodEvents", reflect.TypeOf((*MockClient)(nil).ListPodEvents), ctx, namespace, podName)
 }
 
-// UpdatePipeline mocks base method.
-func (m *MockClient) UpdatePipeline(namespace, name string, obj *v1.Pipeline, data []byte) error {
+// TektonAPIVersion mocks base method.
+func (m *MockClient) TektonAPIVersion() string {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdatePipeline", namespace, name, obj, data)
-	ret0, _ := ret[0].(error)
+	ret := m.ctrl.Call(m, "TektonAPIVersion")
+	ret0, _ := ret[0].(string)
 	return ret0
 }
 
-// UpdatePipeline indicates an expected call of UpdatePipeline.
-func (mr *MockClientMockRecorder) UpdatePipeline(namespace, name, obj, data interface{}) *gomock.Call {
+// TektonAPIVersion indicates an expected call of TektonAPIVersion.
+func (mr *MockClientMockRecorder) TektonAPIVersion() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePipeline", reflect.TypeOf((*MockClient)(nil).UpdatePipeline), namespace, name, obj, data)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TektonAPIVersion", reflect.TypeOf((*MockClient)(nil).TektonAPIVersion))
 }
 
-// UpdatePipelineRun mocks base method.
-func (m *MockClient) UpdatePipelineRun(namespace, name string, obj *v1.PipelineRun, data []byte) error {
+// Update mocks base method.
+func (m *MockClient) Update(ctx context.Context, kind *client.Kind, namespace, name string, obj runtime.Object, data []byte) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdatePipelineRun", namespace, name, obj, data)
+	ret := m.ctrl.Call(m, "Update", ctx, kind, namespace, name, obj, data)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdatePipelineRun indicates an expected call of UpdatePipelineRun.
-func (mr *MockClientMockRecorder) UpdatePipelineRun(namespace, name, obj, data interface{}) *gomock.Call {
+// Update indicates an expected call of Update.
+func (mr *MockClientMockRecorder) Update(ctx, kind, namespace, name, obj, data interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePipelineRun", reflect.TypeOf((*MockClient)(nil).UpdatePipelineRun), namespace, name, obj, data)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockClient)(nil).Update), ctx, kind, namespace, name, obj, data)
 }
 
-// UpdateTask mocks base method.
-func (m *MockClient) UpdateTask(namespace, name string, obj *v1.Task, data []byte) error {
+// Watch mocks base method.
+func (m *MockClient) Watch(ctx context.Context, kind *client.Kind, namespace, name, resourceVersion string) (watch.Interface, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateTask", namespace, name, obj, data)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// UpdateTask indicates an expected call of UpdateTask.
-func (mr *MockClientMockRecorder) UpdateTask(namespace, name, obj, data interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MockClient)(nil).UpdateTask), namespace, name, obj, data)
-}
-
-// UpdateTaskRun mocks base method.
-func (m *MockClient) UpdateTaskRun(namespace, name string, obj *v1.TaskRun, data []byte) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateTaskRun", namespace, name, obj, data)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "Watch", ctx, kind, namespace, name, resourceVersion)
+	ret0, _ := ret[0].(watch.Interface)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// UpdateTaskRun indicates an expected call of UpdateTaskRun.
-func (mr *MockClientMockRecorder) UpdateTaskRun(namespace, name, obj, data interface{}) *gomock.Call {
+// Watch indicates an expected call of Watch.
+func (mr *MockClientMockRecorder) Watch(ctx, kind, namespace, name, resourceVersion interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTaskRun", reflect.TypeOf((*MockClient)(nil).UpdateTaskRun), namespace, name, obj, data)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Watch", reflect.TypeOf((*MockClient)(nil).Watch), ctx, kind, namespace, name, resourceVersion)
 }
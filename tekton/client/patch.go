@@ -0,0 +1,141 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// resourceVersionPath is the path of the resourceVersion of an object, in a JSON patch.
+const resourceVersionPath = "/metadata/resourceVersion"
+
+// patchResource applies the JSON patch data, computed against the representation of the object in
+// k.GroupVersion, and updates obj with the result. The object is read, patched and replaced along
+// with the resourceVersion read, so the update fails with a conflict when the object changes in
+// between. The client then retries conflicts like transient errors: the object is read again and
+// the changes are applied onto its new state.
+func (c *client) patchResource(ctx context.Context, k *Kind, namespace string, name string, obj runtime.Object, data []byte) error {
+	var resp *unstructured.Unstructured
+	err := c.retry(ctx, fmt.Sprintf("update %s %s", k, name), isTransientOrConflictError, func() (err error) {
+		resp, err = c.rebaseResource(ctx, k, namespace, name, data)
+		return err
+	})
+	if err != nil {
+		msg := fmt.Sprintf("Failed to update %s, with error: %v", k, err)
+		log.Printf("[Error] %s", msg)
+		return fmt.Errorf(msg)
+	}
+	return c.fromUnstructured(ctx, k, resp.UnstructuredContent(), obj)
+}
+
+// rebaseResource reads the object, applies the JSON patch data onto it and replaces it in the version
+// served by the cluster. The errors of the requests are returned as is, the object is read without
+// retries since the whole update is retried.
+func (c *client) rebaseResource(ctx context.Context, k *Kind, namespace string, name string, data []byte) (*unstructured.Unstructured, error) {
+	live, err := c.resourceInterface(k, namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	// The object is patched as is when it is served in k.GroupVersion, so the fields unknown to the
+	// provider are kept, and through its conversion to k.GroupVersion otherwise.
+	converted := c.servedVersion(k) != k.GroupVersion.Version
+	var doc []byte
+	if converted {
+		current := k.New()
+		if err := c.fromUnstructured(ctx, k, live.UnstructuredContent(), current); err != nil {
+			return nil, err
+		}
+		doc, err = json.Marshal(current)
+	} else {
+		doc, err = live.MarshalJSON()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	patched, rebased, err := rebasePatch(doc, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply the patch: %v", err)
+	}
+	if rebased {
+		log.Printf("[DEBUG] %s %s changed since it was last read, applying the changes onto resourceVersion %s", k, name, live.GetResourceVersion())
+	}
+
+	desired := &unstructured.Unstructured{}
+	if converted {
+		current := k.New()
+		if err := json.Unmarshal(patched, current); err != nil {
+			return nil, err
+		}
+		served, err := c.toServedVersion(ctx, k, current)
+		if err != nil {
+			return nil, err
+		}
+		resultMap, err := runtime.DefaultUnstructuredConverter.ToUnstructured(served)
+		if err != nil {
+			return nil, fmt.Errorf("failed to translate to Unstructed: %v", err)
+		}
+		desired.SetUnstructuredContent(resultMap)
+	} else if err := desired.UnmarshalJSON(patched); err != nil {
+		return nil, err
+	}
+	desired.SetResourceVersion(live.GetResourceVersion())
+
+	return c.resourceInterface(k, namespace).Update(ctx, desired, metav1.UpdateOptions{})
+}
+
+// rebasePatch applies the JSON patch data onto doc, the current state of the object the patch was
+// computed against. The test of the resourceVersion the patch was computed against is left out, and
+// rebased is true when it doesn't hold. The other operations are applied onto what the object
+// became in between: removing a missing value does nothing, and a missing value to replace is
+// added, along with the missing parents of the values added.
+func rebasePatch(doc, data []byte) (patched []byte, rebased bool, err error) {
+	p, err := jsonpatch.DecodePatch(data)
+	if err != nil {
+		return nil, false, err
+	}
+
+	options := jsonpatch.NewApplyOptions()
+	options.AllowMissingPathOnRemove = true
+	options.EnsurePathExistsOnAdd = true
+	for _, op := range p {
+		path, err := op.Path()
+		if err != nil {
+			return nil, false, err
+		}
+		if op.Kind() == "test" && path == resourceVersionPath {
+			if _, err := (jsonpatch.Patch{op}).Apply(doc); err != nil {
+				rebased = true
+			}
+			continue
+		}
+
+		result, err := jsonpatch.Patch{op}.ApplyWithOptions(doc, options)
+		if err != nil && op.Kind() == "replace" {
+			result, err = jsonpatch.Patch{withOperationKind(op, "add")}.ApplyWithOptions(doc, options)
+		}
+		if err != nil {
+			return nil, false, fmt.Errorf("%s %s: %v", op.Kind(), path, err)
+		}
+		doc = result
+	}
+	return doc, rebased, nil
+}
+
+// withOperationKind returns a copy of the JSON patch operation op, of kind.
+func withOperationKind(op jsonpatch.Operation, kind string) jsonpatch.Operation {
+	result := make(jsonpatch.Operation, len(op))
+	for k, v := range op {
+		result[k] = v
+	}
+	raw := json.RawMessage(fmt.Sprintf("%q", kind))
+	result["op"] = &raw
+	return result
+}
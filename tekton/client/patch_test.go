@@ -0,0 +1,179 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	tektonapiv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestRebasePatch(t *testing.T) {
+	doc := `{"metadata":{"resourceVersion":"2","labels":{"app":"build"}},"spec":{"description":"build","steps":[{"name":"build","image":"golang"}]}}`
+
+	testCases := []struct {
+		Patch           string
+		ExpectedDoc     string
+		ExpectedRebased bool
+		ExpectedError   string
+	}{
+		{
+			Patch:       `[{"op":"test","path":"/metadata/resourceVersion","value":"2"},{"op":"replace","path":"/spec/description","value":"test"}]`,
+			ExpectedDoc: `{"metadata":{"resourceVersion":"2","labels":{"app":"build"}},"spec":{"description":"test","steps":[{"name":"build","image":"golang"}]}}`,
+		},
+		{
+			Patch:           `[{"op":"test","path":"/metadata/resourceVersion","value":"1"},{"op":"add","path":"/metadata/labels/team","value":"ci"}]`,
+			ExpectedDoc:     `{"metadata":{"resourceVersion":"2","labels":{"app":"build","team":"ci"}},"spec":{"description":"build","steps":[{"name":"build","image":"golang"}]}}`,
+			ExpectedRebased: true,
+		},
+		{
+			Patch:       `[{"op":"remove","path":"/spec/steps/0/script"},{"op":"remove","path":"/metadata/annotations/owner"}]`,
+			ExpectedDoc: doc,
+		},
+		{
+			Patch:       `[{"op":"replace","path":"/spec/steps/0/workingDir","value":"/src"}]`,
+			ExpectedDoc: `{"metadata":{"resourceVersion":"2","labels":{"app":"build"}},"spec":{"description":"build","steps":[{"name":"build","image":"golang","workingDir":"/src"}]}}`,
+		},
+		{
+			Patch:       `[{"op":"add","path":"/spec/stepTemplate/env","value":[{"name":"GOFLAGS","value":"-mod=vendor"}]}]`,
+			ExpectedDoc: `{"metadata":{"resourceVersion":"2","labels":{"app":"build"}},"spec":{"description":"build","stepTemplate":{"env":[{"name":"GOFLAGS","value":"-mod=vendor"}]},"steps":[{"name":"build","image":"golang"}]}}`,
+		},
+		{
+			Patch:         `[{"op":"test","path":"/spec/description","value":"deploy"}]`,
+			ExpectedError: "test /spec/description",
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			patched, rebased, err := rebasePatch([]byte(doc), []byte(tc.Patch))
+			if tc.ExpectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.ExpectedError) {
+					t.Fatalf("Expected an error containing %q, given: %v", tc.ExpectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if rebased != tc.ExpectedRebased {
+				t.Fatalf("Expected rebased to be %t, given: %t", tc.ExpectedRebased, rebased)
+			}
+			var expected, given interface{}
+			if err := json.Unmarshal([]byte(tc.ExpectedDoc), &expected); err != nil {
+				t.Fatal(err)
+			}
+			if err := json.Unmarshal(patched, &given); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(expected, given) {
+				t.Fatalf("Documents don't match.\nExpected: %s\nGiven:    %s\n", tc.ExpectedDoc, patched)
+			}
+		})
+	}
+}
+
+// newTestTask returns the unstructured Task build, at resourceVersion, served in version.
+func newTestTask(version, resourceVersion string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "tekton.dev/" + version,
+		"kind":       "Task",
+		"metadata": map[string]interface{}{
+			"name":            "build",
+			"namespace":       "default",
+			"resourceVersion": resourceVersion,
+		},
+		"spec": map[string]interface{}{
+			"description": "build",
+			"steps": []interface{}{
+				map[string]interface{}{"name": "build", "image": "golang"},
+			},
+		},
+	}}
+}
+
+// newTestDynamicClient returns a fake dynamic client holding objects, which serves the Tekton kinds.
+func newTestDynamicClient(objects ...runtime.Object) *dynamicfake.FakeDynamicClient {
+	listKinds := map[schema.GroupVersionResource]string{}
+	for _, k := range kinds {
+		for _, version := range tektonVersions {
+			listKinds[schema.GroupVersionResource{Group: k.GroupVersion.Group, Version: version, Resource: k.Resource}] = k.Kind + "List"
+		}
+	}
+	return dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds, objects...)
+}
+
+func TestUpdateRetriesConflicts(t *testing.T) {
+	dc := newTestDynamicClient(newTestTask("v1", "1"))
+	gvr := schema.GroupVersionResource{Group: "tekton.dev", Version: "v1", Resource: "tasks"}
+
+	// The first update conflicts with a concurrent change of the labels of the task.
+	updates := 0
+	dc.PrependReactor("update", "tasks", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		updates++
+		if updates > 1 {
+			return false, nil, nil
+		}
+		concurrent := newTestTask("v1", "2")
+		concurrent.SetLabels(map[string]string{"team": "ci"})
+		if err := dc.Tracker().Update(gvr, concurrent, "default"); err != nil {
+			return true, nil, err
+		}
+		return true, nil, errors.NewConflict(gvr.GroupResource(), "build", fmt.Errorf("the object has been modified"))
+	})
+
+	c := &client{
+		dynamicClient: dc,
+		tektonVersion: "v1",
+		retryPolicy:   &RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond, MaxBackoff: time.Millisecond},
+	}
+	data := []byte(`[{"op":"test","path":"/metadata/resourceVersion","value":"1"},{"op":"replace","path":"/spec/description","value":"test"}]`)
+	task := &tektonapiv1.Task{}
+	if err := c.Update(context.Background(), TaskKind, "default", "build", task, data); err != nil {
+		t.Fatal(err)
+	}
+
+	if updates != 2 {
+		t.Fatalf("Expected 2 updates, given: %d", updates)
+	}
+	if task.Spec.Description != "test" {
+		t.Fatalf("Expected the description to be updated, given: %q", task.Spec.Description)
+	}
+	if task.Labels["team"] != "ci" {
+		t.Fatalf("Expected the concurrent change of the labels to be kept, given: %v", task.Labels)
+	}
+}
+
+func TestUpdateWithoutRetryPolicyFailsOnConflict(t *testing.T) {
+	dc := newTestDynamicClient(newTestTask("v1", "1"))
+	gvr := schema.GroupVersionResource{Group: "tekton.dev", Version: "v1", Resource: "tasks"}
+	dc.PrependReactor("update", "tasks", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.NewConflict(gvr.GroupResource(), "build", fmt.Errorf("the object has been modified"))
+	})
+
+	c := &client{dynamicClient: dc, tektonVersion: "v1"}
+	data := []byte(`[{"op":"replace","path":"/spec/description","value":"test"}]`)
+	err := c.Update(context.Background(), TaskKind, "default", "build", &tektonapiv1.Task{}, data)
+	if err == nil || !strings.Contains(err.Error(), "the object has been modified") {
+		t.Fatalf("Expected a conflict, given: %v", err)
+	}
+
+	live, err := dc.Resource(gvr).Namespace("default").Get(context.Background(), "build", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if description, _, _ := unstructured.NestedString(live.Object, "spec", "description"); description != "build" {
+		t.Fatalf("Expected the task not to be updated, given the description %q", description)
+	}
+}
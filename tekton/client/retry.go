@@ -0,0 +1,84 @@
+package client
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// RetryPolicy makes the client retry the requests failing with a transient error: 429 Too Many
+// Requests, 5xx server errors and network errors. Creates are only retried on 429 Too Many Requests,
+// and updates are also retried on conflicts, with the changes applied onto the object read again.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts of a request, including the first one.
+	MaxAttempts int
+	// Backoff is the delay before the first retry, doubled after each retry.
+	Backoff time.Duration
+	// MaxBackoff is the maximum delay between two attempts.
+	MaxBackoff time.Duration
+}
+
+// after waits for the delay between two attempts, it is replaced by the tests.
+var after = time.After
+
+// isTransientError reports whether err is an error which may not happen again when the request is
+// retried.
+func isTransientError(err error) bool {
+	if status, ok := err.(errors.APIStatus); ok {
+		code := status.Status().Code
+		return code == http.StatusTooManyRequests || code >= http.StatusInternalServerError
+	}
+	return utilnet.IsConnectionReset(err) || utilnet.IsConnectionRefused(err) || utilnet.IsProbableEOF(err) || utilnet.IsTimeout(err)
+}
+
+// isThrottledError reports whether err is a 429 Too Many Requests, the only transient error for
+// which the API server is known not to have processed the request, e.g. a create.
+func isThrottledError(err error) bool {
+	return errors.IsTooManyRequests(err)
+}
+
+// isTransientOrConflictError reports whether err is a transient error or a conflict, e.g. when the
+// resourceVersion of an updated object is not the current one anymore.
+func isTransientOrConflictError(err error) bool {
+	return errors.IsConflict(err) || isTransientError(err)
+}
+
+// retry calls fn until it succeeds, returns an error for which retriable is false, or the attempts
+// of the retry policy are exhausted. fn is called once when the client has no retry policy.
+func (c *client) retry(ctx context.Context, description string, retriable func(error) bool, fn func() error) error {
+	if c.retryPolicy == nil || c.retryPolicy.MaxAttempts <= 1 {
+		return fn()
+	}
+
+	backoff := wait.Backoff{
+		Duration: c.retryPolicy.Backoff,
+		Factor:   2,
+		Jitter:   0.1,
+		Steps:    c.retryPolicy.MaxAttempts,
+		Cap:      c.retryPolicy.MaxBackoff,
+	}
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || !retriable(err) || attempt >= c.retryPolicy.MaxAttempts {
+			return err
+		}
+
+		delay := backoff.Step()
+		// The API server tells how long to wait when it throttles the requests.
+		if seconds, ok := errors.SuggestsClientDelay(err); ok && time.Duration(seconds)*time.Second > delay {
+			delay = time.Duration(seconds) * time.Second
+		}
+		log.Printf("[DEBUG] Retrying to %s in %s (attempt %d of %d), after error: %v", description, delay, attempt+1, c.retryPolicy.MaxAttempts, err)
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-after(delay):
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"syscall"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestRetry(t *testing.T) {
+	unavailable := errors.NewServiceUnavailable("unavailable")
+	notFound := errors.NewNotFound(schema.GroupResource{Group: "tekton.dev", Resource: "tasks"}, "build")
+
+	testCases := []struct {
+		Policy           *RetryPolicy
+		Retriable        func(error) bool
+		Errors           []error
+		ExpectedAttempts int
+		// ExpectedDelays are the delays between the attempts, before the jitter of up to 10% is added.
+		ExpectedDelays []time.Duration
+		ExpectedError  error
+	}{
+		{
+			Policy:           nil,
+			Retriable:        isTransientError,
+			Errors:           []error{unavailable},
+			ExpectedAttempts: 1,
+			ExpectedError:    unavailable,
+		},
+		{
+			Policy:           &RetryPolicy{MaxAttempts: 1, Backoff: time.Second, MaxBackoff: time.Minute},
+			Retriable:        isTransientError,
+			Errors:           []error{unavailable},
+			ExpectedAttempts: 1,
+			ExpectedError:    unavailable,
+		},
+		{
+			Policy:           &RetryPolicy{MaxAttempts: 5, Backoff: time.Second, MaxBackoff: time.Minute},
+			Retriable:        isTransientError,
+			Errors:           []error{unavailable, unavailable},
+			ExpectedAttempts: 3,
+			ExpectedDelays:   []time.Duration{time.Second, 2 * time.Second},
+		},
+		{
+			Policy:           &RetryPolicy{MaxAttempts: 5, Backoff: time.Second, MaxBackoff: 5 * time.Second},
+			Retriable:        isTransientError,
+			Errors:           []error{unavailable, unavailable, unavailable, unavailable, unavailable},
+			ExpectedAttempts: 5,
+			ExpectedDelays:   []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second},
+			ExpectedError:    unavailable,
+		},
+		{
+			Policy:           &RetryPolicy{MaxAttempts: 5, Backoff: time.Second, MaxBackoff: time.Minute},
+			Retriable:        isTransientError,
+			Errors:           []error{notFound},
+			ExpectedAttempts: 1,
+			ExpectedError:    notFound,
+		},
+		{
+			Policy:           &RetryPolicy{MaxAttempts: 5, Backoff: time.Second, MaxBackoff: time.Minute},
+			Retriable:        isTransientError,
+			Errors:           []error{errors.NewTooManyRequests("throttled", 10)},
+			ExpectedAttempts: 2,
+			ExpectedDelays:   []time.Duration{10 * time.Second},
+		},
+		{
+			Policy:           &RetryPolicy{MaxAttempts: 5, Backoff: 4 * time.Second, MaxBackoff: time.Minute},
+			Retriable:        isTransientError,
+			Errors:           []error{errors.NewTooManyRequests("throttled", 1)},
+			ExpectedAttempts: 2,
+			ExpectedDelays:   []time.Duration{4 * time.Second},
+		},
+		{
+			Policy:           &RetryPolicy{MaxAttempts: 5, Backoff: time.Second, MaxBackoff: time.Minute},
+			Retriable:        isThrottledError,
+			Errors:           []error{unavailable},
+			ExpectedAttempts: 1,
+			ExpectedError:    unavailable,
+		},
+	}
+
+	defer func() { after = time.After }()
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			var delays []time.Duration
+			after = func(d time.Duration) <-chan time.Time {
+				delays = append(delays, d)
+				ch := make(chan time.Time, 1)
+				ch <- time.Now()
+				return ch
+			}
+
+			c := &client{retryPolicy: tc.Policy}
+			attempts := 0
+			err := c.retry(context.Background(), "get task", tc.Retriable, func() error {
+				attempts++
+				if attempts <= len(tc.Errors) {
+					return tc.Errors[attempts-1]
+				}
+				return nil
+			})
+
+			if err != tc.ExpectedError {
+				t.Fatalf("Expected error %v, given: %v", tc.ExpectedError, err)
+			}
+			if attempts != tc.ExpectedAttempts {
+				t.Fatalf("Expected %d attempts, given: %d", tc.ExpectedAttempts, attempts)
+			}
+			if len(delays) != len(tc.ExpectedDelays) {
+				t.Fatalf("Expected the delays %v, given: %v", tc.ExpectedDelays, delays)
+			}
+			for j, expected := range tc.ExpectedDelays {
+				if delays[j] < expected || delays[j] > expected+expected/10 {
+					t.Fatalf("Expected the delays %v, given: %v", tc.ExpectedDelays, delays)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryCanceled(t *testing.T) {
+	defer func() { after = time.After }()
+	after = func(d time.Duration) <-chan time.Time {
+		return make(chan time.Time)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	unavailable := errors.NewServiceUnavailable("unavailable")
+	c := &client{retryPolicy: &RetryPolicy{MaxAttempts: 5, Backoff: time.Second, MaxBackoff: time.Minute}}
+	attempts := 0
+	err := c.retry(ctx, "get task", isTransientError, func() error {
+		attempts++
+		return unavailable
+	})
+	if err != unavailable {
+		t.Fatalf("Expected error %v, given: %v", unavailable, err)
+	}
+	if attempts != 1 {
+		t.Fatalf("Expected 1 attempt, given: %d", attempts)
+	}
+}
+
+func TestIsTransientError(t *testing.T) {
+	gr := schema.GroupResource{Group: "tekton.dev", Resource: "tasks"}
+
+	testCases := []struct {
+		Error             error
+		ExpectedTransient bool
+		ExpectedThrottled bool
+	}{
+		{errors.NewTooManyRequests("throttled", 1), true, true},
+		{errors.NewInternalError(fmt.Errorf("boom")), true, false},
+		{errors.NewServiceUnavailable("unavailable"), true, false},
+		{errors.NewTimeoutError("timeout", 1), true, false},
+		{errors.NewNotFound(gr, "build"), false, false},
+		{errors.NewConflict(gr, "build", fmt.Errorf("conflict")), false, false},
+		{errors.NewInvalid(schema.GroupKind{Group: "tekton.dev", Kind: "Task"}, "build", nil), false, false},
+		{errors.NewForbidden(gr, "build", fmt.Errorf("forbidden")), false, false},
+		{&url.Error{Op: "Get", URL: "https://cluster", Err: io.EOF}, true, false},
+		{&net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}, true, false},
+		{&net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}, true, false},
+		{&url.Error{Op: "Get", URL: "https://cluster", Err: context.DeadlineExceeded}, true, false},
+		{fmt.Errorf("invalid configuration"), false, false},
+	}
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			if transient := isTransientError(tc.Error); transient != tc.ExpectedTransient {
+				t.Fatalf("Expected isTransientError(%v) to be %t", tc.Error, tc.ExpectedTransient)
+			}
+			if throttled := isThrottledError(tc.Error); throttled != tc.ExpectedThrottled {
+				t.Fatalf("Expected isThrottledError(%v) to be %t", tc.Error, tc.ExpectedThrottled)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	tektonapiv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	tektonapiv1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"knative.dev/pkg/apis"
+)
+
+// tektonVersions are the versions of the tekton.dev API group the client can talk, by order of preference.
+// The provider works with v1 objects, which are converted from and to v1beta1 when the cluster doesn't serve v1.
+var tektonVersions = []string{
+	tektonapiv1.SchemeGroupVersion.Version,
+	tektonapiv1beta1.SchemeGroupVersion.Version,
+}
+
+// discoverTektonVersion returns the first of tektonVersions serving all the Tekton Pipelines kinds on the cluster.
+func discoverTektonVersion(d discovery.DiscoveryInterface) (string, error) {
+	for _, version := range tektonVersions {
+		gv := schema.GroupVersion{Group: pipeline.GroupName, Version: version}
+		resources, err := d.ServerResourcesForGroupVersion(gv.String())
+		if err != nil {
+			if errors.IsNotFound(err) {
+				log.Printf("[DEBUG] %s is not served by the cluster", gv)
+				continue
+			}
+			return "", fmt.Errorf("failed to discover the resources of %s: %v", gv, err)
+		}
+		if servesTektonPipelineKinds(resources) {
+			log.Printf("[INFO] Using Tekton API version %s", gv)
+			return version, nil
+		}
+		log.Printf("[DEBUG] %s doesn't serve all of the Tekton kinds", gv)
+	}
+
+	return "", fmt.Errorf("none of the Tekton API versions %v is served by the cluster, is Tekton Pipelines installed?", tektonVersions)
+}
+
+func servesTektonPipelineKinds(resources *metav1.APIResourceList) bool {
+	served := make(map[string]bool, len(resources.APIResources))
+	for _, r := range resources.APIResources {
+		served[r.Name] = true
+	}
+	for _, k := range kinds {
+		if isTektonPipelineKind(k) && !served[k.Resource] {
+			return false
+		}
+	}
+	return true
+}
+
+// TektonAPIVersion implements Client
+func (c *client) TektonAPIVersion() string {
+	if err := c.connect(); err != nil {
+		return ""
+	}
+	return schema.GroupVersion{Group: pipeline.GroupName, Version: c.tektonVersion}.String()
+}
+
+// servedVersion returns the version of kind k served by the cluster.
+func (c *client) servedVersion(k *Kind) string {
+	if k.GroupVersion.Group == pipeline.GroupName {
+		if _, ok := k.Conversions[c.tektonVersion]; ok {
+			return c.tektonVersion
+		}
+	}
+	return k.GroupVersion.Version
+}
+
+func (c *client) resource(k *Kind) schema.GroupVersionResource {
+	return schema.GroupVersionResource{
+		Group:    k.GroupVersion.Group,
+		Version:  c.servedVersion(k),
+		Resource: k.Resource,
+	}
+}
+
+// toServedVersion returns obj converted to the version served by the cluster, with its TypeMeta set.
+func (c *client) toServedVersion(ctx context.Context, k *Kind, obj runtime.Object) (runtime.Object, error) {
+	version := c.servedVersion(k)
+	served := obj
+	if version != k.GroupVersion.Version {
+		in, ok := obj.(apis.Convertible)
+		if !ok {
+			return nil, fmt.Errorf("%s %T is not convertible", k, obj)
+		}
+		out := k.Conversions[version]()
+		if err := out.ConvertFrom(ctx, in); err != nil {
+			msg := fmt.Sprintf("Failed to convert %s to %s, with error: %v", k, version, err)
+			log.Printf("[Error] %s", msg)
+			return nil, fmt.Errorf(msg)
+		}
+		served = out
+	}
+	served.GetObjectKind().SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   k.GroupVersion.Group,
+		Version: version,
+		Kind:    k.Kind,
+	})
+	return served, nil
+}
+
+// newServedVersion returns the object to read an object of the version served by the cluster into,
+// before converting it to obj.
+func (c *client) newServedVersion(k *Kind, obj runtime.Object) runtime.Object {
+	if version := c.servedVersion(k); version != k.GroupVersion.Version {
+		return k.Conversions[version]()
+	}
+	return obj
+}
+
+// fromServedVersion converts served, an object of the version served by the cluster, to obj.
+func (c *client) fromServedVersion(ctx context.Context, k *Kind, served, obj runtime.Object) error {
+	if served == obj {
+		return nil
+	}
+	in, ok := served.(apis.Convertible)
+	if !ok {
+		return fmt.Errorf("%s %T is not convertible", k, served)
+	}
+	out, ok := obj.(apis.Convertible)
+	if !ok {
+		return fmt.Errorf("%s %T is not convertible", k, obj)
+	}
+	if err := in.ConvertTo(ctx, out); err != nil {
+		msg := fmt.Sprintf("Failed to convert %s from %s, with error: %v", k, c.servedVersion(k), err)
+		log.Printf("[Error] %s", msg)
+		return fmt.Errorf(msg)
+	}
+	obj.GetObjectKind().SetGroupVersionKind(k.GroupVersionKind())
+	return nil
+}
+
+// fromUnstructured converts content, an object of the version served by the cluster, to obj.
+func (c *client) fromUnstructured(ctx context.Context, k *Kind, content map[string]interface{}, obj runtime.Object) error {
+	served := c.newServedVersion(k, obj)
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(content, served); err != nil {
+		msg := fmt.Sprintf("Failed to translate unstructed to %s, with error: %v", k, err)
+		log.Printf("[Error] %s", msg)
+		return fmt.Errorf(msg)
+	}
+	return c.fromServedVersion(ctx, k, served, obj)
+}
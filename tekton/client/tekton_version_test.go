@@ -0,0 +1,209 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	tektonapiv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	tektonapiv1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	discoveryfake "k8s.io/client-go/discovery/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// testTektonResources returns the resources served in version of the tekton.dev group, all of the
+// Tekton Pipelines kinds but the ones in missing.
+func testTektonResources(version string, missing ...string) *metav1.APIResourceList {
+	resources := &metav1.APIResourceList{GroupVersion: "tekton.dev/" + version}
+	for _, k := range kinds {
+		if !isTektonPipelineKind(k) || contains(missing, k.Resource) {
+			continue
+		}
+		resources.APIResources = append(resources.APIResources, metav1.APIResource{Name: k.Resource, Kind: k.Kind, Namespaced: k.Namespaced})
+	}
+	return resources
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func TestDiscoverTektonVersion(t *testing.T) {
+	testCases := []struct {
+		Resources       []*metav1.APIResourceList
+		ExpectedVersion string
+		ExpectedError   string
+	}{
+		{
+			Resources:       []*metav1.APIResourceList{testTektonResources("v1")},
+			ExpectedVersion: "v1",
+		},
+		{
+			Resources:       []*metav1.APIResourceList{testTektonResources("v1beta1")},
+			ExpectedVersion: "v1beta1",
+		},
+		{
+			Resources:       []*metav1.APIResourceList{testTektonResources("v1beta1"), testTektonResources("v1")},
+			ExpectedVersion: "v1",
+		},
+		{
+			Resources:       []*metav1.APIResourceList{testTektonResources("v1", "pipelineruns"), testTektonResources("v1beta1")},
+			ExpectedVersion: "v1beta1",
+		},
+		{
+			Resources:     []*metav1.APIResourceList{testTektonResources("v1", "tasks")},
+			ExpectedError: "is Tekton Pipelines installed?",
+		},
+		{
+			ExpectedError: "is Tekton Pipelines installed?",
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			d := &discoveryfake.FakeDiscovery{Fake: &k8stesting.Fake{Resources: tc.Resources}}
+			version, err := discoverTektonVersion(d)
+			if tc.ExpectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.ExpectedError) {
+					t.Fatalf("Expected an error containing %q, given: %v", tc.ExpectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if version != tc.ExpectedVersion {
+				t.Fatalf("Expected the version %q, given: %q", tc.ExpectedVersion, version)
+			}
+		})
+	}
+}
+
+func TestServedVersionRoundTrip(t *testing.T) {
+	task := &tektonapiv1.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: "build", Namespace: "default"},
+		Spec: tektonapiv1.TaskSpec{
+			Description: "Builds the sources",
+			Params: tektonapiv1.ParamSpecs{
+				{Name: "revision", Type: tektonapiv1.ParamTypeString, Default: tektonapiv1.NewStructuredValues("main")},
+			},
+			Steps: []tektonapiv1.Step{
+				{Name: "build", Image: "golang", Script: "go build ./..."},
+			},
+			Results: []tektonapiv1.TaskResult{
+				{Name: "digest", Type: tektonapiv1.ResultsTypeString},
+			},
+		},
+	}
+	pipelineRun := &tektonapiv1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "build-1", Namespace: "default"},
+		Spec: tektonapiv1.PipelineRunSpec{
+			PipelineRef: &tektonapiv1.PipelineRef{Name: "build"},
+			Params: tektonapiv1.Params{
+				{Name: "revision", Value: *tektonapiv1.NewStructuredValues("main")},
+			},
+			TaskRunTemplate: tektonapiv1.PipelineTaskRunTemplate{ServiceAccountName: "builder"},
+		},
+	}
+
+	testCases := []struct {
+		Kind           *Kind
+		Object         runtime.Object
+		ExpectedServed runtime.Object
+	}{
+		{
+			Kind:           TaskKind,
+			Object:         task,
+			ExpectedServed: &tektonapiv1beta1.Task{},
+		},
+		{
+			Kind:           PipelineRunKind,
+			Object:         pipelineRun,
+			ExpectedServed: &tektonapiv1beta1.PipelineRun{},
+		},
+	}
+
+	c := &client{tektonVersion: "v1beta1"}
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			ctx := context.Background()
+			served, err := c.toServedVersion(ctx, tc.Kind, tc.Object.DeepCopyObject())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if fmt.Sprintf("%T", served) != fmt.Sprintf("%T", tc.ExpectedServed) {
+				t.Fatalf("Expected a %T, given: %T", tc.ExpectedServed, served)
+			}
+			if gvk := served.GetObjectKind().GroupVersionKind(); gvk.Version != "v1beta1" || gvk.Kind != tc.Kind.Kind {
+				t.Fatalf("Expected the served object to be typed %s in v1beta1, given: %s", tc.Kind, gvk)
+			}
+
+			content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(served)
+			if err != nil {
+				t.Fatal(err)
+			}
+			obj := tc.Kind.New()
+			if err := c.fromUnstructured(ctx, tc.Kind, content, obj); err != nil {
+				t.Fatal(err)
+			}
+
+			expected, given := specOf(t, tc.Object), specOf(t, obj)
+			if !equality.Semantic.DeepEqual(expected, given) {
+				t.Fatalf("Specs don't match.\nExpected: %v\nGiven:    %v\n", expected, given)
+			}
+			if obj.GetObjectKind().GroupVersionKind() != tc.Kind.GroupVersionKind() {
+				t.Fatalf("Expected the object to be typed %s, given: %s", tc.Kind.GroupVersionKind(), obj.GetObjectKind().GroupVersionKind())
+			}
+		})
+	}
+}
+
+// specOf returns the spec of obj, as decoded from its JSON representation.
+func specOf(t *testing.T, obj runtime.Object) interface{} {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var result map[string]interface{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatal(err)
+	}
+	return result["spec"]
+}
+
+func TestUpdateConvertedResource(t *testing.T) {
+	dc := newTestDynamicClient(newTestTask("v1beta1", "1"))
+	gvr := schema.GroupVersionResource{Group: "tekton.dev", Version: "v1beta1", Resource: "tasks"}
+
+	c := &client{dynamicClient: dc, tektonVersion: "v1beta1"}
+	data := []byte(`[{"op":"test","path":"/metadata/resourceVersion","value":"1"},{"op":"replace","path":"/spec/steps/0/image","value":"golang:1.20"},{"op":"add","path":"/spec/steps/0/timeout","value":"10m0s"}]`)
+	task := &tektonapiv1.Task{}
+	if err := c.Update(context.Background(), TaskKind, "default", "build", task, data); err != nil {
+		t.Fatal(err)
+	}
+
+	if task.APIVersion != "tekton.dev/v1" || task.Spec.Steps[0].Image != "golang:1.20" || task.Spec.Steps[0].Timeout == nil {
+		t.Fatalf("Expected the task to be updated and converted to v1, given: %#v", task)
+	}
+
+	live, err := dc.Resource(gvr).Namespace("default").Get(context.Background(), "build", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	steps, _, _ := unstructured.NestedSlice(live.Object, "spec", "steps")
+	if live.GetAPIVersion() != "tekton.dev/v1beta1" || len(steps) != 1 || steps[0].(map[string]interface{})["image"] != "golang:1.20" {
+		t.Fatalf("Expected the task to be updated in v1beta1, given: %v", live.Object)
+	}
+}
@@ -6,11 +6,17 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/mitchellh/go-homedir"
 	"github.com/rh01/terraform-provider-tekton/tekton/client"
+	"github.com/rh01/terraform-provider-tekton/tekton/utils"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	restclient "k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -73,6 +79,21 @@ func Provider() *schema.Provider {
 					"~/.kube/config"),
 				Description: "Path to the kube config file, defaults to ~/.kube/config",
 			},
+			"config_paths": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Paths to kube config files, merged the way kubectl merges the files of KUBECONFIG. Defaults to the paths of the KUBE_CONFIG_PATHS environment variable. Takes precedence over config_path.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"config_raw": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"config_paths"},
+				Description:   "Content of a kube config file, used instead of the files of config_path and config_paths.",
+			},
 			"config_context": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -102,6 +123,115 @@ func Provider() *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("KUBE_LOAD_CONFIG_FILE", true),
 				Description: "Load local kubeconfig.",
 			},
+			"exec": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Get the credentials from an exec credential plugin, e.g. to use short-lived tokens of a cloud provider.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"api_version": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{"client.authentication.k8s.io/v1", "client.authentication.k8s.io/v1beta1"}, false),
+							Description:  "API version of the ExecCredential returned by the plugin, e.g. client.authentication.k8s.io/v1beta1.",
+						},
+						"command": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Command to execute.",
+						},
+						"args": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "Arguments to pass to the command.",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"env": {
+							Type:        schema.TypeMap,
+							Optional:    true,
+							Description: "Environment variables to set when executing the command.",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+			"server_side_apply": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Update objects with server-side apply instead of JSON patches, the API server then tracks the fields managed by Terraform. Objects are created with the same field manager, creating an object which already exists still fails.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"field_manager": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     client.DefaultFieldManager,
+							Description: "Name of the field manager owning the fields applied by Terraform.",
+						},
+						"force_conflicts": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Take the ownership of fields managed by other field managers when they conflict with the configuration.",
+						},
+					},
+				},
+			},
+			"qps": {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				ValidateFunc: validation.FloatAtLeast(0),
+				Description:  "Maximum number of requests per second to the API server. Defaults to 5.",
+			},
+			"burst": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum number of requests sent at once to the API server, above qps. Defaults to 10.",
+			},
+			"request_timeout": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     utils.ValidateDuration,
+				DiffSuppressFunc: utils.SuppressEquivalentDuration,
+				Description:      "Time after which a request to the API server times out, e.g. 30s. The watches of the objects waited for are not subject to it. Requests don't time out by default.",
+			},
+			"retry": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Retry the requests failing with 429 Too Many Requests, a 5xx server error or a network error. Creates are only retried on 429 Too Many Requests. Updates conflicting with a concurrent change of the object are also retried: the object is read again and the changes are applied onto it.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"max_attempts": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      5,
+							ValidateFunc: validation.IntAtLeast(1),
+							Description:  "Maximum number of attempts of a request, including the first one.",
+						},
+						"backoff": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "1s",
+							ValidateFunc: utils.ValidateDuration,
+							Description:  "Delay before the first retry, doubled after each retry.",
+						},
+						"max_backoff": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "30s",
+							ValidateFunc: utils.ValidateDuration,
+							Description:  "Maximum delay between two attempts.",
+						},
+					},
+				},
+			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
 			"tekton_task":         resourceTektonTask(),
@@ -120,8 +250,33 @@ func Provider() *schema.Provider {
 	return p
 }
 
-func providerConfigure(context context.Context, resourceData *schema.ResourceData, terraformVersion string) (interface{}, diag.Diagnostics) {
+func providerConfigure(ctx context.Context, resourceData *schema.ResourceData, terraformVersion string) (interface{}, diag.Diagnostics) {
+	serverSideApply := expandServerSideApply(resourceData.Get("server_side_apply").([]interface{}))
+	retryPolicy, err := expandRetryPolicy(resourceData.Get("retry").([]interface{}))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	// The values of the connection attributes are not known during the plan when they come from
+	// resources which are not created yet, e.g. a cluster. The client reports it when it is used.
+	if unknown := unknownAttributes(ctx); len(unknown) > 0 {
+		log.Printf("[DEBUG] The provider attributes %s are not known yet", strings.Join(unknown, ", "))
+		return client.NewClient(func() (*restclient.Config, error) {
+			return nil, fmt.Errorf("the provider can't connect to the cluster yet, the values of its attributes %s are not known: "+
+				"they depend on resources which are not created yet. Create these resources first, e.g. with terraform apply -target", strings.Join(unknown, ", "))
+		}, serverSideApply, retryPolicy), nil
+	}
 
+	cfg, err := providerConfig(resourceData, terraformVersion)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	return client.NewClient(func() (*restclient.Config, error) {
+		return cfg, nil
+	}, serverSideApply, retryPolicy), nil
+}
+
+func providerConfig(resourceData *schema.ResourceData, terraformVersion string) (*restclient.Config, error) {
 	var cfg *restclient.Config
 	var err error
 	if resourceData.Get("load_config_file").(bool) {
@@ -130,7 +285,7 @@ func providerConfigure(context context.Context, resourceData *schema.ResourceDat
 	}
 
 	if err != nil {
-		return nil, diag.FromErr(err)
+		return nil, err
 	}
 	if cfg == nil {
 		cfg = &restclient.Config{}
@@ -163,20 +318,96 @@ func providerConfigure(context context.Context, resourceData *schema.ResourceDat
 	if v, ok := resourceData.GetOk("token"); ok {
 		cfg.BearerToken = v.(string)
 	}
+	if v, ok := resourceData.GetOk("exec"); ok {
+		cfg.ExecProvider = expandExecConfig(v.([]interface{}))
+	}
+	if v, ok := resourceData.GetOk("qps"); ok {
+		cfg.QPS = float32(v.(float64))
+	}
+	if v, ok := resourceData.GetOk("burst"); ok {
+		cfg.Burst = v.(int)
+	}
+	if v, ok := resourceData.GetOk("request_timeout"); ok {
+		d, err := time.ParseDuration(v.(string))
+		if err != nil {
+			return nil, err
+		}
+		cfg.Timeout = d
+	}
 
-	return client.NewClient(cfg)
+	return cfg, nil
 }
 
-func tryLoadingConfigFile(resourceData *schema.ResourceData) (*restclient.Config, error) {
-	path, err := homedir.Expand(resourceData.Get("config_path").(string))
+func expandServerSideApply(in []interface{}) *client.ServerSideApply {
+	if len(in) == 0 {
+		return nil
+	}
+
+	result := &client.ServerSideApply{FieldManager: client.DefaultFieldManager}
+	if m, ok := in[0].(map[string]interface{}); ok {
+		if v, ok := m["field_manager"].(string); ok && v != "" {
+			result.FieldManager = v
+		}
+		if v, ok := m["force_conflicts"].(bool); ok {
+			result.ForceConflicts = v
+		}
+	}
+	return result
+}
+
+func expandRetryPolicy(in []interface{}) (*client.RetryPolicy, error) {
+	if len(in) == 0 {
+		return nil, nil
+	}
+
+	// The block is empty when none of its fields is set, the defaults then apply.
+	m, _ := in[0].(map[string]interface{})
+	if m == nil {
+		m = map[string]interface{}{"max_attempts": 5, "backoff": "1s", "max_backoff": "30s"}
+	}
+	backoff, err := time.ParseDuration(m["backoff"].(string))
+	if err != nil {
+		return nil, err
+	}
+	maxBackoff, err := time.ParseDuration(m["max_backoff"].(string))
 	if err != nil {
 		return nil, err
 	}
+	return &client.RetryPolicy{
+		MaxAttempts: m["max_attempts"].(int),
+		Backoff:     backoff,
+		MaxBackoff:  maxBackoff,
+	}, nil
+}
 
-	loader := &clientcmd.ClientConfigLoadingRules{
-		ExplicitPath: path,
+func expandExecConfig(in []interface{}) *clientcmdapi.ExecConfig {
+	if len(in) == 0 || in[0] == nil {
+		return nil
+	}
+
+	m := in[0].(map[string]interface{})
+	result := &clientcmdapi.ExecConfig{
+		APIVersion:      m["api_version"].(string),
+		Command:         m["command"].(string),
+		InteractiveMode: clientcmdapi.IfAvailableExecInteractiveMode,
+	}
+	for _, v := range m["args"].([]interface{}) {
+		result.Args = append(result.Args, v.(string))
 	}
 
+	env := m["env"].(map[string]interface{})
+	names := make([]string, 0, len(env))
+	for k := range env {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	for _, k := range names {
+		result.Env = append(result.Env, clientcmdapi.ExecEnvVar{Name: k, Value: env[k].(string)})
+	}
+	return result
+}
+
+func tryLoadingConfigFile(resourceData *schema.ResourceData) (*restclient.Config, error) {
 	overrides := &clientcmd.ConfigOverrides{}
 	ctxSuffix := "; default context"
 
@@ -203,10 +434,24 @@ func tryLoadingConfigFile(resourceData *schema.ResourceData) (*restclient.Config
 		log.Printf("[DEBUG] Using overidden context: %#v", overrides.Context)
 	}
 
+	if v, ok := resourceData.GetOk("config_raw"); ok {
+		return loadRawConfig(v.(string), overrides, ctxSuffix)
+	}
+
+	paths, err := configPaths(resourceData)
+	if err != nil {
+		return nil, err
+	}
+	// The files are merged, the first file to set a value or a map key wins.
+	loader := &clientcmd.ClientConfigLoadingRules{
+		Precedence: paths,
+	}
+	path := strings.Join(paths, string(filepath.ListSeparator))
+
 	cc := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loader, overrides)
 	cfg, err := cc.ClientConfig()
 	if err != nil {
-		if pathErr, ok := err.(*os.PathError); ok && os.IsNotExist(pathErr.Err) {
+		if clientcmd.IsEmptyConfig(err) {
 			log.Printf("[INFO] Unable to load config file as it doesn't exist at %q", path)
 			return nil, nil
 		}
@@ -216,3 +461,43 @@ func tryLoadingConfigFile(resourceData *schema.ResourceData) (*restclient.Config
 	log.Printf("[INFO] Successfully loaded config file (%s%s)", path, ctxSuffix)
 	return cfg, nil
 }
+
+// configPaths returns the kube config files to load: config_paths, the paths listed in the
+// KUBE_CONFIG_PATHS environment variable or config_path, with the home directory expanded.
+func configPaths(resourceData *schema.ResourceData) ([]string, error) {
+	var paths []string
+	if v, ok := resourceData.GetOk("config_paths"); ok {
+		for _, p := range v.([]interface{}) {
+			paths = append(paths, p.(string))
+		}
+	} else if v := os.Getenv("KUBE_CONFIG_PATHS"); v != "" {
+		paths = filepath.SplitList(v)
+	} else {
+		paths = []string{resourceData.Get("config_path").(string)}
+	}
+
+	for i, p := range paths {
+		path, err := homedir.Expand(p)
+		if err != nil {
+			return nil, err
+		}
+		paths[i] = path
+	}
+	return paths, nil
+}
+
+func loadRawConfig(raw string, overrides *clientcmd.ConfigOverrides, ctxSuffix string) (*restclient.Config, error) {
+	apiConfig, err := clientcmd.Load([]byte(raw))
+	if err != nil {
+		return nil, fmt.Errorf("[DEBUG] Failed to parse config_raw: %s", err)
+	}
+
+	cc := clientcmd.NewNonInteractiveClientConfig(*apiConfig, overrides.CurrentContext, overrides, nil)
+	cfg, err := cc.ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("[DEBUG] Failed to load config (config_raw%s): %s", ctxSuffix, err)
+	}
+
+	log.Printf("[INFO] Successfully loaded config (config_raw%s)", ctxSuffix)
+	return cfg, nil
+}
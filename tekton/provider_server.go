@@ -0,0 +1,57 @@
+package tekton
+
+import (
+	"context"
+	"log"
+	"sort"
+
+	"github.com/hashicorp/go-cty/cty/msgpack"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// unknownAttributesKey is the context key of the provider attributes whose value is not known yet.
+type unknownAttributesKey struct{}
+
+// providerServer records the provider attributes whose value is not known yet, e.g. the host of a
+// cluster created in the same apply, before configuring the provider. The SDK hands them to
+// ConfigureContextFunc as if they were not set.
+type providerServer struct {
+	*schema.GRPCProviderServer
+	provider *schema.Provider
+}
+
+// NewProviderServer returns the gRPC server of the provider.
+func NewProviderServer() tfprotov5.ProviderServer {
+	p := Provider()
+	return &providerServer{
+		GRPCProviderServer: schema.NewGRPCProviderServer(p),
+		provider:           p,
+	}
+}
+
+func (s *providerServer) ConfigureProvider(ctx context.Context, req *tfprotov5.ConfigureProviderRequest) (*tfprotov5.ConfigureProviderResponse, error) {
+	if req.Config != nil {
+		config, err := msgpack.Unmarshal(req.Config.MsgPack, schema.InternalMap(s.provider.Schema).CoreConfigSchema().ImpliedType())
+		if err != nil {
+			log.Printf("[DEBUG] Failed to decode the provider configuration: %s", err)
+		} else if config.IsKnown() && !config.IsNull() {
+			var unknown []string
+			for name := range s.provider.Schema {
+				if !config.GetAttr(name).IsWhollyKnown() {
+					unknown = append(unknown, name)
+				}
+			}
+			sort.Strings(unknown)
+			ctx = context.WithValue(ctx, unknownAttributesKey{}, unknown)
+		}
+	}
+
+	return s.GRPCProviderServer.ConfigureProvider(ctx, req)
+}
+
+// unknownAttributes returns the names of the provider attributes whose value is not known yet.
+func unknownAttributes(ctx context.Context) []string {
+	unknown, _ := ctx.Value(unknownAttributesKey{}).([]string)
+	return unknown
+}
@@ -0,0 +1,124 @@
+package tekton
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/go-cty/cty/msgpack"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/rh01/terraform-provider-tekton/tekton/client"
+	tektonapiv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+)
+
+const testKubeConfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: test
+  cluster:
+    server: https://127.0.0.1:6443
+users:
+- name: test
+  user:
+    token: secret
+contexts:
+- name: test
+  context:
+    cluster: test
+    user: test
+current-context: test
+`
+
+// testProviderConfig returns the provider configuration setting attributes, the others being unset.
+func testProviderConfig(t *testing.T, p *schema.Provider, attributes map[string]cty.Value) *tfprotov5.DynamicValue {
+	ty := schema.InternalMap(p.Schema).CoreConfigSchema().ImpliedType()
+	values := map[string]cty.Value{}
+	for name, attributeType := range ty.AttributeTypes() {
+		switch {
+		case attributes[name] != cty.NilVal:
+			values[name] = attributes[name]
+		case attributeType.IsListType():
+			values[name] = cty.ListValEmpty(attributeType.ElementType())
+		default:
+			values[name] = cty.NullVal(attributeType)
+		}
+	}
+
+	data, err := msgpack.Marshal(cty.ObjectVal(values), ty)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &tfprotov5.DynamicValue{MsgPack: data}
+}
+
+func TestConfigureProviderUnknownAttributes(t *testing.T) {
+	testCases := []struct {
+		Attributes      map[string]cty.Value
+		ExpectedUnknown []string
+	}{
+		{
+			Attributes:      map[string]cty.Value{"host": cty.UnknownVal(cty.String), "token": cty.StringVal("secret")},
+			ExpectedUnknown: []string{"host"},
+		},
+		{
+			Attributes:      map[string]cty.Value{"config_raw": cty.UnknownVal(cty.String)},
+			ExpectedUnknown: []string{"config_raw"},
+		},
+		{
+			Attributes:      map[string]cty.Value{"config_raw": cty.StringVal(testKubeConfig)},
+			ExpectedUnknown: nil,
+		},
+		{
+			Attributes:      map[string]cty.Value{"host": cty.StringVal("https://127.0.0.1:6443"), "token": cty.StringVal("secret"), "load_config_file": cty.False},
+			ExpectedUnknown: nil,
+		},
+	}
+
+	// No kube config file is found in the home directory.
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("KUBE_CONFIG_PATHS", "")
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			server := NewProviderServer().(*providerServer)
+
+			// The attributes not known yet are recorded before the provider is configured.
+			var unknown []string
+			configure := server.provider.ConfigureContextFunc
+			server.provider.ConfigureContextFunc = func(ctx context.Context, resourceData *schema.ResourceData) (interface{}, diag.Diagnostics) {
+				unknown = unknownAttributes(ctx)
+				return configure(ctx, resourceData)
+			}
+
+			resp, err := server.ConfigureProvider(context.Background(), &tfprotov5.ConfigureProviderRequest{
+				TerraformVersion: "1.5.0",
+				Config:           testProviderConfig(t, server.provider, tc.Attributes),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(resp.Diagnostics) > 0 {
+				t.Fatalf("Expected no diagnostics, given: %v", resp.Diagnostics[0])
+			}
+			if !reflect.DeepEqual(unknown, tc.ExpectedUnknown) {
+				t.Fatalf("Expected the unknown attributes %v, given: %v", tc.ExpectedUnknown, unknown)
+			}
+			if len(tc.ExpectedUnknown) == 0 {
+				return
+			}
+
+			// The client reports the attributes not known yet once it is used.
+			cli := server.provider.Meta().(client.Client)
+			err = cli.Get(context.Background(), client.TaskKind, "default", "build", &tektonapiv1.Task{})
+			expected := fmt.Sprintf("the values of its attributes %s are not known", strings.Join(tc.ExpectedUnknown, ", "))
+			if err == nil || !strings.Contains(err.Error(), expected) {
+				t.Fatalf("Expected an error containing %q, given: %v", expected, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,261 @@
+package tekton
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/rh01/terraform-provider-tekton/tekton/client"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+func TestExpandRetryPolicy(t *testing.T) {
+	defaultPolicy := &client.RetryPolicy{MaxAttempts: 5, Backoff: time.Second, MaxBackoff: 30 * time.Second}
+
+	testCases := []struct {
+		// Config is the provider configuration, whose retry block is expanded with the schema defaults.
+		Config         map[string]interface{}
+		ExpectedPolicy *client.RetryPolicy
+	}{
+		{
+			Config:         map[string]interface{}{},
+			ExpectedPolicy: nil,
+		},
+		{
+			Config: map[string]interface{}{
+				"retry": []interface{}{map[string]interface{}{}},
+			},
+			ExpectedPolicy: defaultPolicy,
+		},
+		{
+			Config: map[string]interface{}{
+				"retry": []interface{}{map[string]interface{}{"max_attempts": 3}},
+			},
+			ExpectedPolicy: &client.RetryPolicy{MaxAttempts: 3, Backoff: time.Second, MaxBackoff: 30 * time.Second},
+		},
+		{
+			Config: map[string]interface{}{
+				"retry": []interface{}{map[string]interface{}{"max_attempts": 10, "backoff": "500ms", "max_backoff": "1m"}},
+			},
+			ExpectedPolicy: &client.RetryPolicy{MaxAttempts: 10, Backoff: 500 * time.Millisecond, MaxBackoff: time.Minute},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			resourceData := schema.TestResourceDataRaw(t, Provider().Schema, tc.Config)
+			policy, err := expandRetryPolicy(resourceData.Get("retry").([]interface{}))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(policy, tc.ExpectedPolicy) {
+				t.Fatalf("Expected the retry policy %+v, given: %+v", tc.ExpectedPolicy, policy)
+			}
+		})
+	}
+
+	// An empty block may also be passed as a nil element, the defaults of the schema then apply.
+	policy, err := expandRetryPolicy([]interface{}{nil})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(policy, defaultPolicy) {
+		t.Fatalf("Expected the retry policy %+v, given: %+v", defaultPolicy, policy)
+	}
+
+	if _, err := expandRetryPolicy([]interface{}{map[string]interface{}{"max_attempts": 5, "backoff": "soon", "max_backoff": "30s"}}); err == nil {
+		t.Fatal("Expected an error for an invalid backoff")
+	}
+}
+
+func TestExpandExecConfig(t *testing.T) {
+	testCases := []struct {
+		// Config is the provider configuration, whose exec block is expanded.
+		Config         map[string]interface{}
+		ExpectedConfig *clientcmdapi.ExecConfig
+	}{
+		{
+			Config:         map[string]interface{}{},
+			ExpectedConfig: nil,
+		},
+		{
+			Config: map[string]interface{}{
+				"exec": []interface{}{map[string]interface{}{
+					"api_version": "client.authentication.k8s.io/v1",
+					"command":     "kubelogin",
+				}},
+			},
+			ExpectedConfig: &clientcmdapi.ExecConfig{
+				APIVersion:      "client.authentication.k8s.io/v1",
+				Command:         "kubelogin",
+				InteractiveMode: clientcmdapi.IfAvailableExecInteractiveMode,
+			},
+		},
+		{
+			Config: map[string]interface{}{
+				"exec": []interface{}{map[string]interface{}{
+					"api_version": "client.authentication.k8s.io/v1beta1",
+					"command":     "aws",
+					"args":        []interface{}{"eks", "get-token", "--cluster-name", "ci"},
+					"env":         map[string]interface{}{"AWS_REGION": "eu-west-1", "AWS_PROFILE": "ci", "AWS_ROLE_ARN": "arn:aws:iam::123456789012:role/ci"},
+				}},
+			},
+			ExpectedConfig: &clientcmdapi.ExecConfig{
+				APIVersion: "client.authentication.k8s.io/v1beta1",
+				Command:    "aws",
+				Args:       []string{"eks", "get-token", "--cluster-name", "ci"},
+				Env: []clientcmdapi.ExecEnvVar{
+					{Name: "AWS_PROFILE", Value: "ci"},
+					{Name: "AWS_REGION", Value: "eu-west-1"},
+					{Name: "AWS_ROLE_ARN", Value: "arn:aws:iam::123456789012:role/ci"},
+				},
+				InteractiveMode: clientcmdapi.IfAvailableExecInteractiveMode,
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			resourceData := schema.TestResourceDataRaw(t, Provider().Schema, tc.Config)
+			// The environment variables are sorted by name, whatever the order of the map.
+			for n := 0; n < 10; n++ {
+				config := expandExecConfig(resourceData.Get("exec").([]interface{}))
+				if !reflect.DeepEqual(config, tc.ExpectedConfig) {
+					t.Fatalf("Expected the exec config %+v, given: %+v", tc.ExpectedConfig, config)
+				}
+			}
+		})
+	}
+}
+
+func TestConfigPaths(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	testCases := []struct {
+		Config          map[string]interface{}
+		KubeConfigPaths string
+		ExpectedPaths   []string
+	}{
+		{
+			Config:        map[string]interface{}{"config_path": "~/.kube/ci"},
+			ExpectedPaths: []string{filepath.Join(home, ".kube/ci")},
+		},
+		{
+			Config:          map[string]interface{}{"config_path": "~/.kube/ci"},
+			KubeConfigPaths: strings.Join([]string{"/etc/kube/ci", "~/.kube/config"}, string(filepath.ListSeparator)),
+			ExpectedPaths:   []string{"/etc/kube/ci", filepath.Join(home, ".kube/config")},
+		},
+		{
+			Config:          map[string]interface{}{"config_paths": []interface{}{"~/.kube/ci", "/etc/kube/config"}},
+			KubeConfigPaths: "/etc/kube/ci",
+			ExpectedPaths:   []string{filepath.Join(home, ".kube/ci"), "/etc/kube/config"},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			t.Setenv("KUBE_CONFIG_PATHS", tc.KubeConfigPaths)
+			resourceData := schema.TestResourceDataRaw(t, Provider().Schema, tc.Config)
+			paths, err := configPaths(resourceData)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(paths, tc.ExpectedPaths) {
+				t.Fatalf("Expected the paths %v, given: %v", tc.ExpectedPaths, paths)
+			}
+		})
+	}
+}
+
+func TestTryLoadingConfigFile(t *testing.T) {
+	// The cluster and the context are in one file, the credentials of the user in the other.
+	dir := t.TempDir()
+	clusterPath := filepath.Join(dir, "cluster")
+	userPath := filepath.Join(dir, "user")
+	files := map[string]string{
+		clusterPath: `apiVersion: v1
+kind: Config
+clusters:
+- name: ci
+  cluster:
+    server: https://ci.example.com:6443
+contexts:
+- name: ci
+  context:
+    cluster: ci
+    user: ci
+current-context: ci
+`,
+		userPath: `apiVersion: v1
+kind: Config
+users:
+- name: ci
+  user:
+    token: ci-token
+`,
+	}
+	for path, content := range files {
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("HOME", dir)
+
+	testCases := []struct {
+		Config          map[string]interface{}
+		KubeConfigPaths string
+		ExpectedHost    string
+		ExpectedToken   string
+		ExpectedError   string
+	}{
+		{
+			Config:        map[string]interface{}{"config_paths": []interface{}{clusterPath, userPath}},
+			ExpectedHost:  "https://ci.example.com:6443",
+			ExpectedToken: "ci-token",
+		},
+		{
+			KubeConfigPaths: strings.Join([]string{userPath, clusterPath}, string(filepath.ListSeparator)),
+			ExpectedHost:    "https://ci.example.com:6443",
+			ExpectedToken:   "ci-token",
+		},
+		{
+			Config:        map[string]interface{}{"config_paths": []interface{}{clusterPath, userPath}, "config_raw": testKubeConfig},
+			ExpectedHost:  "https://127.0.0.1:6443",
+			ExpectedToken: "secret",
+		},
+		{
+			Config:        map[string]interface{}{"config_raw": "apiVersion: v1\nkind: Config\nclusters: {"},
+			ExpectedError: "Failed to parse config_raw",
+		},
+		{
+			Config:        map[string]interface{}{"config_raw": testKubeConfig, "config_context": "missing"},
+			ExpectedError: "Failed to load config (config_raw; overriden context; config ctx: missing)",
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			t.Setenv("KUBE_CONFIG_PATHS", tc.KubeConfigPaths)
+			resourceData := schema.TestResourceDataRaw(t, Provider().Schema, tc.Config)
+			cfg, err := tryLoadingConfigFile(resourceData)
+			if tc.ExpectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.ExpectedError) {
+					t.Fatalf("Expected an error containing %q, given: %v", tc.ExpectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cfg == nil || cfg.Host != tc.ExpectedHost || cfg.BearerToken != tc.ExpectedToken {
+				t.Fatalf("Expected the host %q and the token %q, given: %+v", tc.ExpectedHost, tc.ExpectedToken, cfg)
+			}
+		})
+	}
+}
@@ -1,165 +1,168 @@
 package tekton
 
 import (
-	"fmt"
+	"context"
 	"log"
 	"time"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/rh01/terraform-provider-tekton/tekton/client"
 	"github.com/rh01/terraform-provider-tekton/tekton/schema/pipeline"
-	"github.com/rh01/terraform-provider-tekton/tekton/schema/task"
 	"github.com/rh01/terraform-provider-tekton/tekton/utils"
 	"github.com/rh01/terraform-provider-tekton/tekton/utils/patch"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	tektonapiv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 )
 
 func resourceTektonPipeline() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceTektonPipelineCreate,
-		Read:   resourceTektonPipelineRead,
-		Update: resourceTektonPipelineUpdate,
-		Delete: resourceTektonPipelineDelete,
-		Exists: resourceTektonPipelineExists,
+		CreateContext: resourceTektonPipelineCreate,
+		ReadContext:   resourceTektonPipelineRead,
+		UpdateContext: resourceTektonPipelineUpdate,
+		DeleteContext: resourceTektonPipelineDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(40 * time.Minute),
 			Delete: schema.DefaultTimeout(5 * time.Minute),
 		},
-		Schema: pipeline.TektonPipelineFields(),
+		CustomizeDiff: resourceTektonPipelineCustomizeDiff,
+		Schema:        pipeline.TektonPipelineFields(),
 	}
 }
 
-func resourceTektonPipelineCreate(resourceData *schema.ResourceData, meta interface{}) error {
+func resourceTektonPipelineCustomizeDiff(ctx context.Context, resourceDiff *schema.ResourceDiff, meta interface{}) error {
+	if !resourceDiff.NewValueKnown("spec") {
+		return nil
+	}
+
+	spec, _ := resourceDiff.Get("spec").([]interface{})
+	if !pipeline.HasMatrix(spec) {
+		return nil
+	}
+
+	return pipeline.ValidateMatrixCombinationsCount(spec, maxMatrixCombinationsCount(ctx, meta))
+}
+
+// maxMatrixCombinationsCount returns the default-max-matrix-combinations-count of the Tekton
+// installation, or the Tekton default when it cannot be read.
+func maxMatrixCombinationsCount(ctx context.Context, meta interface{}) int {
+	max := config.DefaultMaxMatrixCombinationsCount
+	if cli, ok := meta.(client.Client); ok {
+		defaults, err := cli.GetDefaultsConfig(ctx)
+		if err != nil {
+			log.Printf("[DEBUG] Falling back to the default max matrix combinations count %d: %s", max, err)
+		} else {
+			max = defaults.DefaultMaxMatrixCombinationsCount
+		}
+	}
+	return max
+}
+
+func resourceTektonPipelineCreate(ctx context.Context, resourceData *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	cli := (meta).(client.Client)
 
-	dv, err := task.FromResourceData(resourceData)
+	dv, err := pipeline.FromResourceData(resourceData)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[INFO] Creating new tekton pipeline: %#v", dv)
-	if err := cli.CreateTask(dv); err != nil {
-		return err
+	if err := cli.Create(ctx, client.PipelineKind, dv); err != nil {
+		return diag.FromErr(err)
 	}
 	log.Printf("[INFO] Submitted new tekton pipeline: %#v", dv)
-	if err := task.ToResourceData(*dv, resourceData); err != nil {
-		return err
-	}
 	resourceData.SetId(utils.BuildId(dv.ObjectMeta))
 
-	// Wait for tekton pipeline instance's status phase to be succeeded:
-	name := dv.ObjectMeta.Name
-	namespace := dv.ObjectMeta.Namespace
-
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"Creating"},
-		Target:  []string{"Succeeded"},
-		Timeout: resourceData.Timeout(schema.TimeoutCreate),
-		Refresh: func() (interface{}, string, error) {
-			var err error
-			dv, err = cli.GetTask(namespace, name)
-			if err != nil {
-				if errors.IsNotFound(err) {
-					log.Printf("[DEBUG] tekton pipeline %s is not created yet", name)
-					return dv, "Creating", nil
-				}
-				return dv, "", err
-			}
-
-			log.Printf("[DEBUG] tekton pipeline %s is being created", name)
-			return dv, "Creating", nil
-		},
-	}
-
-	if _, err := stateConf.WaitForState(); err != nil {
-		return fmt.Errorf("%s", err)
-	}
-	return task.ToResourceData(*dv, resourceData)
+	// A pipeline has no status to wait for, read back the object as stored by the API server.
+	return resourceTektonPipelineRead(ctx, resourceData, meta)
 }
 
-func resourceTektonPipelineRead(resourceData *schema.ResourceData, meta interface{}) error {
+func resourceTektonPipelineRead(ctx context.Context, resourceData *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	cli := (meta).(client.Client)
 
 	namespace, name, err := utils.IdParts(resourceData.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[INFO] Reading tekton pipeline %s", name)
 
-	dv, err := cli.GetTask(namespace, name)
-	if err != nil {
+	dv := &tektonapiv1.Pipeline{}
+	if err := cli.Get(ctx, client.PipelineKind, namespace, name, dv); err != nil {
+		if errors.IsNotFound(err) {
+			log.Printf("[WARN] tekton pipeline %s not found, removing it from the state", name)
+			resourceData.SetId("")
+			return nil
+		}
 		log.Printf("[DEBUG] Received error: %#v", err)
-		return err
+		return diag.FromErr(err)
 	}
 	log.Printf("[INFO] Received tekton pipeline: %#v", dv)
 
-	return task.ToResourceData(*dv, resourceData)
+	if err := resourceData.Set("api_version", cli.TektonAPIVersion()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(pipeline.ToResourceData(*dv, resourceData))
 }
 
-func resourceTektonPipelineUpdate(resourceData *schema.ResourceData, meta interface{}) error {
+func resourceTektonPipelineUpdate(ctx context.Context, resourceData *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	cli := (meta).(client.Client)
 
 	namespace, name, err := utils.IdParts(resourceData.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	ops := task.AppendPatchOps("", "", resourceData, make([]patch.PatchOperation, 0, 0))
+	ops, err := pipeline.AppendPatchOps("", "", resourceData, make([]patch.PatchOperation, 0, 0))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	data, err := ops.MarshalJSON()
 	if err != nil {
-		return fmt.Errorf("[DEBUG] Failed to marshal update operations: %s", err)
+		return diag.Errorf("[DEBUG] Failed to marshal update operations: %s", err)
 	}
 
 	log.Printf("[INFO] Updating tekton pipeline: %s", ops)
-	out := &tektonapiv1.Task{}
-	if err := cli.UpdateTask(namespace, name, out, data); err != nil {
-		return err
+	// The desired object is applied instead of the patch when server-side apply is enabled.
+	out, err := pipeline.FromResourceData(resourceData)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := cli.Update(ctx, client.PipelineKind, namespace, name, out, data); err != nil {
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[INFO] Submitted updated tekton pipeline: %#v", out)
 
-	return resourceTektonPipelineRead(resourceData, meta)
+	return resourceTektonPipelineRead(ctx, resourceData, meta)
 }
 
-func resourceTektonPipelineDelete(resourceData *schema.ResourceData, meta interface{}) error {
+func resourceTektonPipelineDelete(ctx context.Context, resourceData *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	cli := (meta).(client.Client)
 
 	namespace, name, err := utils.IdParts(resourceData.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[INFO] Deleting tekton pipeline: %#v", name)
-	if err := cli.DeleteTask(namespace, name); err != nil {
-		return err
+	if err := cli.Delete(ctx, client.PipelineKind, namespace, name); err != nil {
+		if !errors.IsNotFound(err) {
+			return diag.FromErr(err)
+		}
+		log.Printf("[WARN] tekton pipeline %s was already deleted", name)
+		resourceData.SetId("")
+		return nil
 	}
 
 	// Wait for tekton pipeline instance to be removed:
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"Deleting"},
-		Timeout: resourceData.Timeout(schema.TimeoutDelete),
-		Refresh: func() (interface{}, string, error) {
-			dv, err := cli.GetTask(namespace, name)
-			if err != nil {
-				if errors.IsNotFound(err) {
-					return nil, "", nil
-				}
-				return dv, "", err
-			}
-
-			log.Printf("[DEBUG] tekton pipeline %s is being deleted", dv.GetName())
-			return dv, "Deleting", nil
-		},
-	}
-
-	if _, err := stateConf.WaitForState(); err != nil {
-		return fmt.Errorf("%s", err)
+	if err := waitForDeletion(ctx, cli, client.PipelineKind, namespace, name, resourceData.Timeout(schema.TimeoutDelete)); err != nil {
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[INFO] tekton pipeline %s deleted", name)
@@ -167,22 +170,3 @@ func resourceTektonPipelineDelete(resourceData *schema.ResourceData, meta interf
 	resourceData.SetId("")
 	return nil
 }
-
-func resourceTektonPipelineExists(resourceData *schema.ResourceData, meta interface{}) (bool, error) {
-	cli := (meta).(client.Client)
-
-	namespace, name, err := utils.IdParts(resourceData.Id())
-	if err != nil {
-		return false, err
-	}
-
-	log.Printf("[INFO] Checking tekton pipeline %s", name)
-	if _, err := cli.GetTask(namespace, name); err != nil {
-		if statusErr, ok := err.(*errors.StatusError); ok && statusErr.ErrStatus.Code == 404 {
-			return false, nil
-		}
-		log.Printf("[DEBUG] Received error: %#v", err)
-		return true, err
-	}
-	return true, nil
-}
@@ -1,165 +1,199 @@
 package tekton
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"time"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/rh01/terraform-provider-tekton/tekton/client"
+	"github.com/rh01/terraform-provider-tekton/tekton/schema/pipeline"
 	"github.com/rh01/terraform-provider-tekton/tekton/schema/pipeline_run"
-	"github.com/rh01/terraform-provider-tekton/tekton/schema/task"
 	"github.com/rh01/terraform-provider-tekton/tekton/utils"
 	"github.com/rh01/terraform-provider-tekton/tekton/utils/patch"
 	tektonapiv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"knative.dev/pkg/apis"
 )
 
 func resourceTektonPipelineRun() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceTektonPipelineRunCreate,
-		Read:   resourceTektonPipelineRunRead,
-		Update: resourceTektonPipelineRunUpdate,
-		Delete: resourceTektonPipelineRunDelete,
-		Exists: resourceTektonPipelineRunExists,
+		CreateContext: resourceTektonPipelineRunCreate,
+		ReadContext:   resourceTektonPipelineRunRead,
+		UpdateContext: resourceTektonPipelineRunUpdate,
+		DeleteContext: resourceTektonPipelineRunDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(40 * time.Minute),
 			Delete: schema.DefaultTimeout(5 * time.Minute),
 		},
-		Schema: pipeline_run.TektonPipelineRunFields(),
+		CustomizeDiff: resourceTektonPipelineRunCustomizeDiff,
+		Schema:        pipeline_run.TektonPipelineRunFields(),
 	}
 }
 
-func resourceTektonPipelineRunCreate(resourceData *schema.ResourceData, meta interface{}) error {
+func resourceTektonPipelineRunCustomizeDiff(ctx context.Context, resourceDiff *schema.ResourceDiff, meta interface{}) error {
+	if !resourceDiff.NewValueKnown("spec.0.pipeline_spec") {
+		return nil
+	}
+
+	pipelineSpec, _ := resourceDiff.Get("spec.0.pipeline_spec").([]interface{})
+	if !pipeline.HasMatrix(pipelineSpec) {
+		return nil
+	}
+
+	return pipeline.ValidateMatrixCombinationsCount(pipelineSpec, maxMatrixCombinationsCount(ctx, meta))
+}
+
+func resourceTektonPipelineRunCreate(ctx context.Context, resourceData *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	cli := (meta).(client.Client)
 
-	dv, err := task.FromResourceData(resourceData)
+	dv, err := pipeline_run.FromResourceData(resourceData)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[INFO] Creating new tekton pipelinerun: %#v", dv)
-	if err := cli.CreateTask(dv); err != nil {
-		return err
+	if err := cli.Create(ctx, client.PipelineRunKind, dv); err != nil {
+		return diag.FromErr(err)
 	}
 	log.Printf("[INFO] Submitted new tekton pipelinerun: %#v", dv)
-	if err := task.ToResourceData(*dv, resourceData); err != nil {
-		return err
-	}
 	resourceData.SetId(utils.BuildId(dv.ObjectMeta))
 
-	// Wait for tekton pipelinerun instance's status phase to be succeeded:
+	// Wait for tekton pipelinerun instance's Succeeded condition to reach the wait_for state:
 	name := dv.ObjectMeta.Name
 	namespace := dv.ObjectMeta.Namespace
 
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"Creating"},
-		Target:  []string{"Succeeded"},
-		Timeout: resourceData.Timeout(schema.TimeoutCreate),
-		Refresh: func() (interface{}, string, error) {
-			var err error
-			dv, err = cli.GetTask(namespace, name)
-			if err != nil {
-				if errors.IsNotFound(err) {
-					log.Printf("[DEBUG] tekton pipelinerun %s is not created yet", name)
-					return dv, "Creating", nil
-				}
-				return dv, "", err
-			}
+	obj, err := waitForRun(ctx, cli, resourceData, client.PipelineRunKind, namespace, name)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var diags diag.Diagnostics
+	if pr, ok := obj.(*tektonapiv1.PipelineRun); ok && pr.Status.GetCondition(apis.ConditionSucceeded).IsFalse() {
+		diags = runFailureDiagnostics(resourceData, pipelineRunFailure(ctx, cli, pr, resourceData.Get("failure_log_lines").(int)))
+		if diags.HasError() {
+			return diags
+		}
+	}
 
-			log.Printf("[DEBUG] tekton pipelinerun %s is being created", name)
-			return dv, "Creating", nil
-		},
+	return append(diags, resourceTektonPipelineRunRead(ctx, resourceData, meta)...)
+}
+
+// pipelineRunFailure describes why the pipelinerun failed, using the first failed TaskRun it finds.
+func pipelineRunFailure(ctx context.Context, cli client.Client, pr *tektonapiv1.PipelineRun, logLines int) *runFailureError {
+	condition := pr.Status.GetCondition(apis.ConditionSucceeded)
+
+	for _, child := range pr.Status.ChildReferences {
+		if child.Kind != "TaskRun" {
+			continue
+		}
+		tr := &tektonapiv1.TaskRun{}
+		if err := cli.Get(ctx, client.TaskRunKind, pr.Namespace, child.Name, tr); err != nil {
+			log.Printf("[DEBUG] Failed to get taskrun %s of tekton pipelinerun %s: %s", child.Name, pr.Name, err)
+			continue
+		}
+		if c := tr.Status.GetCondition(apis.ConditionSucceeded); c.IsFalse() {
+			return &runFailureError{
+				summary: fmt.Sprintf("tekton pipelinerun %s failed (%s): taskrun %s of pipeline task %s failed (%s): %s", pr.Name, condition.Reason, tr.Name, child.PipelineTaskName, c.Reason, c.Message),
+				detail:  taskRunFailureDetail(ctx, cli, tr, logLines),
+			}
+		}
 	}
 
-	if _, err := stateConf.WaitForState(); err != nil {
-		return fmt.Errorf("%s", err)
+	return &runFailureError{
+		summary: fmt.Sprintf("tekton pipelinerun %s failed (%s): %s", pr.Name, condition.Reason, condition.Message),
 	}
-	return task.ToResourceData(*dv, resourceData)
 }
 
-func resourceTektonPipelineRunRead(resourceData *schema.ResourceData, meta interface{}) error {
+func resourceTektonPipelineRunRead(ctx context.Context, resourceData *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	cli := (meta).(client.Client)
 
 	namespace, name, err := utils.IdParts(resourceData.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[INFO] Reading tekton pipelinerun %s", name)
 
-	dv, err := cli.GetTask(namespace, name)
-	if err != nil {
+	dv := &tektonapiv1.PipelineRun{}
+	if err := cli.Get(ctx, client.PipelineRunKind, namespace, name, dv); err != nil {
+		if errors.IsNotFound(err) {
+			log.Printf("[WARN] tekton pipelinerun %s not found, removing it from the state", name)
+			resourceData.SetId("")
+			return nil
+		}
 		log.Printf("[DEBUG] Received error: %#v", err)
-		return err
+		return diag.FromErr(err)
 	}
 	log.Printf("[INFO] Received tekton pipelinerun: %#v", dv)
 
-	return task.ToResourceData(*dv, resourceData)
+	if err := resourceData.Set("api_version", cli.TektonAPIVersion()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(pipeline_run.ToResourceData(*dv, resourceData))
 }
 
-func resourceTektonPipelineRunUpdate(resourceData *schema.ResourceData, meta interface{}) error {
+func resourceTektonPipelineRunUpdate(ctx context.Context, resourceData *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	cli := (meta).(client.Client)
 
 	namespace, name, err := utils.IdParts(resourceData.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	ops := task.AppendPatchOps("", "", resourceData, make([]patch.PatchOperation, 0, 0))
+	ops := pipeline_run.AppendPatchOps("", "", resourceData, make([]patch.PatchOperation, 0, 0))
 	data, err := ops.MarshalJSON()
 	if err != nil {
-		return fmt.Errorf("[DEBUG] Failed to marshal update operations: %s", err)
+		return diag.Errorf("[DEBUG] Failed to marshal update operations: %s", err)
 	}
 
 	log.Printf("[INFO] Updating tekton pipelinerun: %s", ops)
-	out := &tektonapiv1.Task{}
-	if err := cli.UpdateTask(namespace, name, out, data); err != nil {
-		return err
+	// The desired object is applied instead of the patch when server-side apply is enabled.
+	out, err := pipeline_run.FromResourceData(resourceData)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := cli.Update(ctx, client.PipelineRunKind, namespace, name, out, data); err != nil {
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[INFO] Submitted updated tekton pipelinerun: %#v", out)
 
-	return resourceTektonPipelineRunRead(resourceData, meta)
+	return resourceTektonPipelineRunRead(ctx, resourceData, meta)
 }
 
-func resourceTektonPipelineRunDelete(resourceData *schema.ResourceData, meta interface{}) error {
+func resourceTektonPipelineRunDelete(ctx context.Context, resourceData *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	cli := (meta).(client.Client)
 
 	namespace, name, err := utils.IdParts(resourceData.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Deleting tekton pipelinerun: %#v", name)
-	if err := cli.DeleteTask(namespace, name); err != nil {
-		return err
+	if status, ok := pipeline_run.DestroySpecStatus(resourceData.Get("destroy_behavior").(string)); ok {
+		if err := stopPipelineRun(ctx, cli, resourceData, namespace, name, status); err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
-	// Wait for tekton pipelinerun instance to be removed:
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"Deleting"},
-		Timeout: resourceData.Timeout(schema.TimeoutDelete),
-		Refresh: func() (interface{}, string, error) {
-			dv, err := cli.GetTask(namespace, name)
-			if err != nil {
-				if errors.IsNotFound(err) {
-					return nil, "", nil
-				}
-				return dv, "", err
-			}
-
-			log.Printf("[DEBUG] tekton pipelinerun %s is being deleted", dv.GetName())
-			return dv, "Deleting", nil
-		},
+	log.Printf("[INFO] Deleting tekton pipelinerun: %#v", name)
+	if err := cli.Delete(ctx, client.PipelineRunKind, namespace, name); err != nil {
+		if !errors.IsNotFound(err) {
+			return diag.FromErr(err)
+		}
+		log.Printf("[WARN] tekton pipelinerun %s was already deleted", name)
+		resourceData.SetId("")
+		return nil
 	}
 
-	if _, err := stateConf.WaitForState(); err != nil {
-		return fmt.Errorf("%s", err)
+	// Wait for tekton pipelinerun instance to be removed:
+	if err := waitForDeletion(ctx, cli, client.PipelineRunKind, namespace, name, resourceData.Timeout(schema.TimeoutDelete)); err != nil {
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[INFO] tekton pipelinerun %s deleted", name)
@@ -168,21 +202,37 @@ func resourceTektonPipelineRunDelete(resourceData *schema.ResourceData, meta int
 	return nil
 }
 
-func resourceTektonPipelineRunExists(resourceData *schema.ResourceData, meta interface{}) (bool, error) {
-	cli := (meta).(client.Client)
+// stopPipelineRun sets spec.status of a pipelinerun which is still running and waits for it to complete,
+// so that its finally tasks get to run before it is deleted.
+func stopPipelineRun(ctx context.Context, cli client.Client, resourceData *schema.ResourceData, namespace, name string, status tektonapiv1.PipelineRunSpecStatus) error {
+	pr := &tektonapiv1.PipelineRun{}
+	if err := cli.Get(ctx, client.PipelineRunKind, namespace, name, pr); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if state := runState(pr.Status.GetCondition(apis.ConditionSucceeded)); state == runStateSucceeded || state == runStateFailed {
+		return nil
+	}
 
-	namespace, name, err := utils.IdParts(resourceData.Id())
+	ops := patch.PatchOperations{&patch.AddOperation{Path: "/spec/status", Value: string(status)}}
+	data, err := ops.MarshalJSON()
 	if err != nil {
-		return false, err
+		return fmt.Errorf("[DEBUG] Failed to marshal update operations: %s", err)
 	}
 
-	log.Printf("[INFO] Checking tekton pipelinerun %s", name)
-	if _, err := cli.GetTask(namespace, name); err != nil {
-		if statusErr, ok := err.(*errors.StatusError); ok && statusErr.ErrStatus.Code == 404 {
-			return false, nil
-		}
-		log.Printf("[DEBUG] Received error: %#v", err)
-		return true, err
+	out, err := pipeline_run.FromResourceData(resourceData)
+	if err != nil {
+		return err
 	}
-	return true, nil
+	out.Spec.Status = status
+
+	log.Printf("[INFO] Stopping tekton pipelinerun %s: %s", name, ops)
+	if err := cli.Update(ctx, client.PipelineRunKind, namespace, name, out, data); err != nil {
+		return err
+	}
+
+	_, err = waitForRunCompletion(ctx, cli, client.PipelineRunKind, namespace, name, resourceData.Timeout(schema.TimeoutDelete))
+	return err
 }
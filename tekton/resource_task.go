@@ -1,11 +1,11 @@
 package tekton
 
 import (
-	"fmt"
+	"context"
 	"log"
 	"time"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/rh01/terraform-provider-tekton/tekton/client"
 	"github.com/rh01/terraform-provider-tekton/tekton/schema/task"
@@ -17,13 +17,12 @@ import (
 
 func resourceTektonTask() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceTektonTaskCreate,
-		Read:   resourceTektonTaskRead,
-		Update: resourceTektonTaskUpdate,
-		Delete: resourceTektonTaskDelete,
-		Exists: resourceTektonTaskExists,
+		CreateContext: resourceTektonTaskCreate,
+		ReadContext:   resourceTektonTaskRead,
+		UpdateContext: resourceTektonTaskUpdate,
+		DeleteContext: resourceTektonTaskDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(40 * time.Minute),
@@ -33,132 +32,107 @@ func resourceTektonTask() *schema.Resource {
 	}
 }
 
-func resourceTektonTaskCreate(resourceData *schema.ResourceData, meta interface{}) error {
+func resourceTektonTaskCreate(ctx context.Context, resourceData *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	cli := (meta).(client.Client)
 
 	dv, err := task.FromResourceData(resourceData)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[INFO] Creating new tekton task: %#v", dv)
-	if err := cli.CreateTask(dv); err != nil {
-		return err
+	if err := cli.Create(ctx, client.TaskKind, dv); err != nil {
+		return diag.FromErr(err)
 	}
 	log.Printf("[INFO] Submitted new tekton task: %#v", dv)
-	if err := task.ToResourceData(*dv, resourceData); err != nil {
-		return err
-	}
 	resourceData.SetId(utils.BuildId(dv.ObjectMeta))
 
-	// Wait for tekton task instance's status phase to be succeeded:
-	name := dv.ObjectMeta.Name
-	namespace := dv.ObjectMeta.Namespace
-
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"Creating"},
-		Target:  []string{"Succeeded"},
-		Timeout: resourceData.Timeout(schema.TimeoutCreate),
-		Refresh: func() (interface{}, string, error) {
-			var err error
-			dv, err = cli.GetTask(namespace, name)
-			if err != nil {
-				if errors.IsNotFound(err) {
-					log.Printf("[DEBUG] tekton task %s is not created yet", name)
-					return dv, "Creating", nil
-				}
-				return dv, "", err
-			}
-
-			log.Printf("[DEBUG] tekton task %s is being created", name)
-			return dv, "Creating", nil
-		},
-	}
-
-	if _, err := stateConf.WaitForState(); err != nil {
-		return fmt.Errorf("%s", err)
-	}
-	return task.ToResourceData(*dv, resourceData)
+	// A task has no status to wait for, read back the object as stored by the API server.
+	return resourceTektonTaskRead(ctx, resourceData, meta)
 }
 
-func resourceTektonTaskRead(resourceData *schema.ResourceData, meta interface{}) error {
+func resourceTektonTaskRead(ctx context.Context, resourceData *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	cli := (meta).(client.Client)
 
 	namespace, name, err := utils.IdParts(resourceData.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[INFO] Reading tekton task %s", name)
 
-	dv, err := cli.GetTask(namespace, name)
-	if err != nil {
+	dv := &tektonapiv1.Task{}
+	if err := cli.Get(ctx, client.TaskKind, namespace, name, dv); err != nil {
+		if errors.IsNotFound(err) {
+			log.Printf("[WARN] tekton task %s not found, removing it from the state", name)
+			resourceData.SetId("")
+			return nil
+		}
 		log.Printf("[DEBUG] Received error: %#v", err)
-		return err
+		return diag.FromErr(err)
 	}
 	log.Printf("[INFO] Received tekton task: %#v", dv)
 
-	return task.ToResourceData(*dv, resourceData)
+	if err := resourceData.Set("api_version", cli.TektonAPIVersion()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(task.ToResourceData(*dv, resourceData))
 }
 
-func resourceTektonTaskUpdate(resourceData *schema.ResourceData, meta interface{}) error {
+func resourceTektonTaskUpdate(ctx context.Context, resourceData *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	cli := (meta).(client.Client)
 
 	namespace, name, err := utils.IdParts(resourceData.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	ops := task.AppendPatchOps("", "", resourceData, make([]patch.PatchOperation, 0, 0))
+	ops, err := task.AppendPatchOps("", "", resourceData, make([]patch.PatchOperation, 0, 0))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	data, err := ops.MarshalJSON()
 	if err != nil {
-		return fmt.Errorf("[DEBUG] Failed to marshal update operations: %s", err)
+		return diag.Errorf("[DEBUG] Failed to marshal update operations: %s", err)
 	}
 
 	log.Printf("[INFO] Updating tekton task: %s", ops)
-	out := &tektonapiv1.Task{}
-	if err := cli.UpdateTask(namespace, name, out, data); err != nil {
-		return err
+	// The desired object is applied instead of the patch when server-side apply is enabled.
+	out, err := task.FromResourceData(resourceData)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := cli.Update(ctx, client.TaskKind, namespace, name, out, data); err != nil {
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[INFO] Submitted updated tekton task: %#v", out)
 
-	return resourceTektonTaskRead(resourceData, meta)
+	return resourceTektonTaskRead(ctx, resourceData, meta)
 }
 
-func resourceTektonTaskDelete(resourceData *schema.ResourceData, meta interface{}) error {
+func resourceTektonTaskDelete(ctx context.Context, resourceData *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	cli := (meta).(client.Client)
 
 	namespace, name, err := utils.IdParts(resourceData.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[INFO] Deleting tekton task: %#v", name)
-	if err := cli.DeleteTask(namespace, name); err != nil {
-		return err
+	if err := cli.Delete(ctx, client.TaskKind, namespace, name); err != nil {
+		if !errors.IsNotFound(err) {
+			return diag.FromErr(err)
+		}
+		log.Printf("[WARN] tekton task %s was already deleted", name)
+		resourceData.SetId("")
+		return nil
 	}
 
 	// Wait for tekton task instance to be removed:
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"Deleting"},
-		Timeout: resourceData.Timeout(schema.TimeoutDelete),
-		Refresh: func() (interface{}, string, error) {
-			dv, err := cli.GetTask(namespace, name)
-			if err != nil {
-				if errors.IsNotFound(err) {
-					return nil, "", nil
-				}
-				return dv, "", err
-			}
-
-			log.Printf("[DEBUG] tekton task %s is being deleted", dv.GetName())
-			return dv, "Deleting", nil
-		},
-	}
-
-	if _, err := stateConf.WaitForState(); err != nil {
-		return fmt.Errorf("%s", err)
+	if err := waitForDeletion(ctx, cli, client.TaskKind, namespace, name, resourceData.Timeout(schema.TimeoutDelete)); err != nil {
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[INFO] tekton task %s deleted", name)
@@ -166,22 +140,3 @@ func resourceTektonTaskDelete(resourceData *schema.ResourceData, meta interface{
 	resourceData.SetId("")
 	return nil
 }
-
-func resourceTektonTaskExists(resourceData *schema.ResourceData, meta interface{}) (bool, error) {
-	cli := (meta).(client.Client)
-
-	namespace, name, err := utils.IdParts(resourceData.Id())
-	if err != nil {
-		return false, err
-	}
-
-	log.Printf("[INFO] Checking tekton task %s", name)
-	if _, err := cli.GetTask(namespace, name); err != nil {
-		if statusErr, ok := err.(*errors.StatusError); ok && statusErr.ErrStatus.Code == 404 {
-			return false, nil
-		}
-		log.Printf("[DEBUG] Received error: %#v", err)
-		return true, err
-	}
-	return true, nil
-}
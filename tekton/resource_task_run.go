@@ -1,30 +1,30 @@
 package tekton
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"time"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/rh01/terraform-provider-tekton/tekton/client"
-	"github.com/rh01/terraform-provider-tekton/tekton/schema/task"
 	"github.com/rh01/terraform-provider-tekton/tekton/schema/task_run"
 	"github.com/rh01/terraform-provider-tekton/tekton/utils"
 	"github.com/rh01/terraform-provider-tekton/tekton/utils/patch"
 	tektonapiv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"knative.dev/pkg/apis"
 )
 
 func resourceTektonTaskRun() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceTektonTaskRunCreate,
-		Read:   resourceTektonTaskRunRead,
-		Update: resourceTektonTaskRunUpdate,
-		Delete: resourceTektonTaskRunDelete,
-		Exists: resourceTektonTaskRunExists,
+		CreateContext: resourceTektonTaskRunCreate,
+		ReadContext:   resourceTektonTaskRunRead,
+		UpdateContext: resourceTektonTaskRunUpdate,
+		DeleteContext: resourceTektonTaskRunDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(40 * time.Minute),
@@ -34,132 +34,125 @@ func resourceTektonTaskRun() *schema.Resource {
 	}
 }
 
-func resourceTektonTaskRunCreate(resourceData *schema.ResourceData, meta interface{}) error {
+func resourceTektonTaskRunCreate(ctx context.Context, resourceData *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	cli := (meta).(client.Client)
 
-	dv, err := task.FromResourceData(resourceData)
+	dv, err := task_run.FromResourceData(resourceData)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[INFO] Creating new tekton taskrun: %#v", dv)
-	if err := cli.CreateTask(dv); err != nil {
-		return err
+	if err := cli.Create(ctx, client.TaskRunKind, dv); err != nil {
+		return diag.FromErr(err)
 	}
 	log.Printf("[INFO] Submitted new tekton taskrun: %#v", dv)
-	if err := task.ToResourceData(*dv, resourceData); err != nil {
-		return err
-	}
 	resourceData.SetId(utils.BuildId(dv.ObjectMeta))
 
-	// Wait for tekton taskrun instance's status phase to be succeeded:
+	// Wait for tekton taskrun instance's Succeeded condition to reach the wait_for state:
 	name := dv.ObjectMeta.Name
 	namespace := dv.ObjectMeta.Namespace
 
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"Creating"},
-		Target:  []string{"Succeeded"},
-		Timeout: resourceData.Timeout(schema.TimeoutCreate),
-		Refresh: func() (interface{}, string, error) {
-			var err error
-			dv, err = cli.GetTask(namespace, name)
-			if err != nil {
-				if errors.IsNotFound(err) {
-					log.Printf("[DEBUG] tekton taskrun %s is not created yet", name)
-					return dv, "Creating", nil
-				}
-				return dv, "", err
+	obj, err := waitForRun(ctx, cli, resourceData, client.TaskRunKind, namespace, name)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var diags diag.Diagnostics
+	if tr, ok := obj.(*tektonapiv1.TaskRun); ok {
+		if c := tr.Status.GetCondition(apis.ConditionSucceeded); c.IsFalse() {
+			failure := &runFailureError{
+				summary: fmt.Sprintf("tekton taskrun %s failed (%s): %s", name, c.Reason, c.Message),
+				detail:  taskRunFailureDetail(ctx, cli, tr, resourceData.Get("failure_log_lines").(int)),
 			}
-
-			log.Printf("[DEBUG] tekton taskrun %s is being created", name)
-			return dv, "Creating", nil
-		},
+			diags = runFailureDiagnostics(resourceData, failure)
+			if diags.HasError() {
+				return diags
+			}
+		}
 	}
 
-	if _, err := stateConf.WaitForState(); err != nil {
-		return fmt.Errorf("%s", err)
-	}
-	return task.ToResourceData(*dv, resourceData)
+	return append(diags, resourceTektonTaskRunRead(ctx, resourceData, meta)...)
 }
 
-func resourceTektonTaskRunRead(resourceData *schema.ResourceData, meta interface{}) error {
+func resourceTektonTaskRunRead(ctx context.Context, resourceData *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	cli := (meta).(client.Client)
 
 	namespace, name, err := utils.IdParts(resourceData.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[INFO] Reading tekton taskrun %s", name)
 
-	dv, err := cli.GetTask(namespace, name)
-	if err != nil {
+	dv := &tektonapiv1.TaskRun{}
+	if err := cli.Get(ctx, client.TaskRunKind, namespace, name, dv); err != nil {
+		if errors.IsNotFound(err) {
+			log.Printf("[WARN] tekton taskrun %s not found, removing it from the state", name)
+			resourceData.SetId("")
+			return nil
+		}
 		log.Printf("[DEBUG] Received error: %#v", err)
-		return err
+		return diag.FromErr(err)
 	}
 	log.Printf("[INFO] Received tekton taskrun: %#v", dv)
 
-	return task.ToResourceData(*dv, resourceData)
+	if err := resourceData.Set("api_version", cli.TektonAPIVersion()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diag.FromErr(task_run.ToResourceData(*dv, resourceData))
 }
 
-func resourceTektonTaskRunUpdate(resourceData *schema.ResourceData, meta interface{}) error {
+func resourceTektonTaskRunUpdate(ctx context.Context, resourceData *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	cli := (meta).(client.Client)
 
 	namespace, name, err := utils.IdParts(resourceData.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	ops := task.AppendPatchOps("", "", resourceData, make([]patch.PatchOperation, 0, 0))
+	ops := task_run.AppendPatchOps("", "", resourceData, make([]patch.PatchOperation, 0, 0))
 	data, err := ops.MarshalJSON()
 	if err != nil {
-		return fmt.Errorf("[DEBUG] Failed to marshal update operations: %s", err)
+		return diag.Errorf("[DEBUG] Failed to marshal update operations: %s", err)
 	}
 
 	log.Printf("[INFO] Updating tekton taskrun: %s", ops)
-	out := &tektonapiv1.Task{}
-	if err := cli.UpdateTask(namespace, name, out, data); err != nil {
-		return err
+	// The desired object is applied instead of the patch when server-side apply is enabled.
+	out, err := task_run.FromResourceData(resourceData)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := cli.Update(ctx, client.TaskRunKind, namespace, name, out, data); err != nil {
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[INFO] Submitted updated tekton taskrun: %#v", out)
 
-	return resourceTektonTaskRunRead(resourceData, meta)
+	return resourceTektonTaskRunRead(ctx, resourceData, meta)
 }
 
-func resourceTektonTaskRunDelete(resourceData *schema.ResourceData, meta interface{}) error {
+func resourceTektonTaskRunDelete(ctx context.Context, resourceData *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	cli := (meta).(client.Client)
 
 	namespace, name, err := utils.IdParts(resourceData.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[INFO] Deleting tekton taskrun: %#v", name)
-	if err := cli.DeleteTask(namespace, name); err != nil {
-		return err
+	if err := cli.Delete(ctx, client.TaskRunKind, namespace, name); err != nil {
+		if !errors.IsNotFound(err) {
+			return diag.FromErr(err)
+		}
+		log.Printf("[WARN] tekton taskrun %s was already deleted", name)
+		resourceData.SetId("")
+		return nil
 	}
 
 	// Wait for tekton taskrun instance to be removed:
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"Deleting"},
-		Timeout: resourceData.Timeout(schema.TimeoutDelete),
-		Refresh: func() (interface{}, string, error) {
-			dv, err := cli.GetTask(namespace, name)
-			if err != nil {
-				if errors.IsNotFound(err) {
-					return nil, "", nil
-				}
-				return dv, "", err
-			}
-
-			log.Printf("[DEBUG] tekton taskrun %s is being deleted", dv.GetName())
-			return dv, "Deleting", nil
-		},
-	}
-
-	if _, err := stateConf.WaitForState(); err != nil {
-		return fmt.Errorf("%s", err)
+	if err := waitForDeletion(ctx, cli, client.TaskRunKind, namespace, name, resourceData.Timeout(schema.TimeoutDelete)); err != nil {
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[INFO] tekton taskrun %s deleted", name)
@@ -167,22 +160,3 @@ func resourceTektonTaskRunDelete(resourceData *schema.ResourceData, meta interfa
 	resourceData.SetId("")
 	return nil
 }
-
-func resourceTektonTaskRunExists(resourceData *schema.ResourceData, meta interface{}) (bool, error) {
-	cli := (meta).(client.Client)
-
-	namespace, name, err := utils.IdParts(resourceData.Id())
-	if err != nil {
-		return false, err
-	}
-
-	log.Printf("[INFO] Checking tekton taskrun %s", name)
-	if _, err := cli.GetTask(namespace, name); err != nil {
-		if statusErr, ok := err.(*errors.StatusError); ok && statusErr.ErrStatus.Code == 404 {
-			return false, nil
-		}
-		log.Printf("[DEBUG] Received error: %#v", err)
-		return true, err
-	}
-	return true, nil
-}
@@ -0,0 +1,66 @@
+package tekton
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/rh01/terraform-provider-tekton/tekton/client"
+	tektonapiv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+)
+
+// runFailureError is the error of a failed TaskRun or PipelineRun. Its detail holds the logs of the
+// failed steps and the events of the TaskRun pod.
+type runFailureError struct {
+	summary string
+	detail  string
+}
+
+func (e *runFailureError) Error() string {
+	if e.detail == "" {
+		return e.summary
+	}
+	return e.summary + "\n\n" + e.detail
+}
+
+// taskRunFailureDetail collects the last logLines lines of logs of the failed steps of the TaskRun
+// and the events of its pod. Failures to read them are logged and skipped.
+func taskRunFailureDetail(ctx context.Context, cli client.Client, tr *tektonapiv1.TaskRun, logLines int) string {
+	podName := tr.Status.PodName
+	if podName == "" {
+		return ""
+	}
+
+	var b strings.Builder
+	for _, step := range tr.Status.Steps {
+		terminated := step.Terminated
+		if terminated == nil || terminated.ExitCode == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "Step %s of pod %s exited with code %d (%s)", step.Name, podName, terminated.ExitCode, terminated.Reason)
+		if logLines == 0 {
+			b.WriteString("\n\n")
+			continue
+		}
+		logs, err := cli.GetPodLogs(ctx, tr.Namespace, podName, step.Container, int64(logLines))
+		if err != nil {
+			log.Printf("[DEBUG] Failed to get logs of step %s of tekton taskrun %s: %s", step.Name, tr.Name, err)
+			b.WriteString("\n\n")
+			continue
+		}
+		fmt.Fprintf(&b, ", last %d lines of logs:\n%s\n\n", logLines, strings.TrimRight(logs, "\n"))
+	}
+
+	events, err := cli.ListPodEvents(ctx, tr.Namespace, podName)
+	if err != nil {
+		log.Printf("[DEBUG] Failed to list events of pod %s of tekton taskrun %s: %s", podName, tr.Name, err)
+	} else if len(events) > 0 {
+		fmt.Fprintf(&b, "Events of pod %s:\n", podName)
+		for _, e := range events {
+			fmt.Fprintf(&b, "  %s\t%s\t%s\n", e.Type, e.Reason, e.Message)
+		}
+	}
+
+	return strings.TrimRight(b.String(), "\n")
+}
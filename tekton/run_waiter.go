@@ -0,0 +1,106 @@
+package tekton
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/rh01/terraform-provider-tekton/tekton/client"
+	"github.com/rh01/terraform-provider-tekton/tekton/schema/task_run"
+	"k8s.io/apimachinery/pkg/runtime"
+	"knative.dev/pkg/apis"
+)
+
+const (
+	runStatePending   = "Pending"
+	runStateRunning   = "Running"
+	runStateSucceeded = "Succeeded"
+	runStateFailed    = "Failed"
+)
+
+// runObject is a TaskRun or a PipelineRun.
+type runObject interface {
+	runtime.Object
+	GetStatusCondition() apis.ConditionAccessor
+}
+
+// runState maps the Succeeded condition of a TaskRun or PipelineRun to the state used by the waiters.
+func runState(condition *apis.Condition) string {
+	switch {
+	case condition == nil:
+		return runStatePending
+	case condition.IsTrue():
+		return runStateSucceeded
+	case condition.IsFalse():
+		return runStateFailed
+	default:
+		return runStateRunning
+	}
+}
+
+// waitForRun waits until the run reaches the state requested by the wait_for attribute and returns
+// the last object it read, or nil when wait_for is none. The wait is aborted when ctx is done.
+func waitForRun(ctx context.Context, cli client.Client, resourceData *schema.ResourceData, kind *client.Kind, namespace, name string) (runtime.Object, error) {
+	var target []string
+	switch resourceData.Get("wait_for").(string) {
+	case task_run.WaitForNone:
+		return nil, nil
+	case task_run.WaitForStarted:
+		target = []string{runStateRunning, runStateSucceeded, runStateFailed}
+	default:
+		target = []string{runStateSucceeded, runStateFailed}
+	}
+
+	return waitForRunState(ctx, cli, kind, namespace, name, target, resourceData.Timeout(schema.TimeoutCreate))
+}
+
+// waitForRunCompletion waits until the run has succeeded or failed, whatever wait_for is set to.
+func waitForRunCompletion(ctx context.Context, cli client.Client, kind *client.Kind, namespace, name string, timeout time.Duration) (runtime.Object, error) {
+	return waitForRunState(ctx, cli, kind, namespace, name, []string{runStateSucceeded, runStateFailed}, timeout)
+}
+
+func waitForRunState(ctx context.Context, cli client.Client, kind *client.Kind, namespace, name string, target []string, timeout time.Duration) (runtime.Object, error) {
+	description := fmt.Sprintf("tekton %s %s", strings.ToLower(kind.Kind), name)
+
+	return waitForObject(ctx, cli, kind, namespace, name, timeout, func(obj runtime.Object, deleted bool) (bool, error) {
+		if deleted {
+			return false, fmt.Errorf("the run was deleted outside of Terraform")
+		}
+		if obj == nil {
+			log.Printf("[DEBUG] %s is not created yet", description)
+			return false, nil
+		}
+		run, ok := obj.(runObject)
+		if !ok {
+			return false, fmt.Errorf("not a run: %T", obj)
+		}
+
+		state := runState(run.GetStatusCondition().GetCondition(apis.ConditionSucceeded))
+		log.Printf("[DEBUG] %s is %s", description, state)
+		for _, t := range target {
+			if state == t {
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+}
+
+// runFailureDiagnostics reports a failed run as an error, or as a warning when on_failure is set to
+// warn the user only.
+func runFailureDiagnostics(resourceData *schema.ResourceData, failure *runFailureError) diag.Diagnostics {
+	severity := diag.Error
+	if resourceData.Get("on_failure").(string) == task_run.OnFailureWarn {
+		log.Printf("[WARN] %s", failure)
+		severity = diag.Warning
+	}
+	return diag.Diagnostics{{
+		Severity: severity,
+		Summary:  failure.summary,
+		Detail:   failure.detail,
+	}}
+}
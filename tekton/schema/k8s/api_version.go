@@ -0,0 +1,17 @@
+package k8s
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// APIVersionSchema is the API version the object is read and written with, which depends on the
+// versions served by the cluster.
+func APIVersionSchema(objectName string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Description: fmt.Sprintf("The API version used to read and write the %s, discovered from the versions served by the cluster.", objectName),
+		Computed:    true,
+	}
+}
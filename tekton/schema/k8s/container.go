@@ -0,0 +1,204 @@
+package k8s
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	v1 "k8s.io/api/core/v1"
+)
+
+// containerFieldSchema returns a copy of the schema of the field name of a container, with description.
+func containerFieldSchema(name, description string) *schema.Schema {
+	s := *containerFields(true)[name]
+	s.Description = description
+	return &s
+}
+
+// EnvSchema returns the schema of the environment variables of a container, whose value is either
+// set or read from a source, e.g. a key of a Secret.
+func EnvSchema(description string) *schema.Schema {
+	return containerFieldSchema("env", description)
+}
+
+func ExpandEnv(in []interface{}) ([]v1.EnvVar, error) {
+	if len(in) == 0 {
+		return nil, nil
+	}
+
+	return expandContainerEnv(in)
+}
+
+func FlattenEnv(in []v1.EnvVar) []interface{} {
+	return flattenContainerEnvs(in)
+}
+
+// EnvFromSchema returns the schema of the ConfigMaps and Secrets whose keys populate the environment
+// variables of a container.
+func EnvFromSchema(description string) *schema.Schema {
+	return containerFieldSchema("env_from", description)
+}
+
+func ExpandEnvFrom(in []interface{}) ([]v1.EnvFromSource, error) {
+	if len(in) == 0 {
+		return nil, nil
+	}
+
+	return expandContainerEnvFrom(in)
+}
+
+func FlattenEnvFrom(in []v1.EnvFromSource) []interface{} {
+	return flattenContainerEnvFroms(in)
+}
+
+func PortsSchema(description string) *schema.Schema {
+	return containerFieldSchema("port", description)
+}
+
+func ExpandPorts(in []interface{}) ([]v1.ContainerPort, error) {
+	if len(in) == 0 {
+		return nil, nil
+	}
+
+	ports, err := expandContainerPort(in)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]v1.ContainerPort, 0, len(ports))
+	for _, p := range ports {
+		result = append(result, *p)
+	}
+	return result, nil
+}
+
+func FlattenPorts(in []v1.ContainerPort) []interface{} {
+	return flattenContainerPorts(in)
+}
+
+func ProbeSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Description: description,
+		Optional:    true,
+		MaxItems:    1,
+		Elem:        probeSchema(),
+	}
+}
+
+func ExpandProbe(l []interface{}) *v1.Probe {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	return expandProbe(l)
+}
+
+func FlattenProbe(in *v1.Probe) []interface{} {
+	if in == nil {
+		return []interface{}{}
+	}
+
+	return flattenProbe(in)
+}
+
+// LifecycleSchema returns the schema of the actions to take in response to the lifecycle events of
+// a container.
+func LifecycleSchema(description string) *schema.Schema {
+	return containerFieldSchema("lifecycle", description)
+}
+
+func ExpandLifecycle(l []interface{}) *v1.Lifecycle {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	return expandLifeCycle(l)
+}
+
+func FlattenLifecycle(in *v1.Lifecycle) []interface{} {
+	if in == nil {
+		return []interface{}{}
+	}
+
+	return flattenLifeCycle(in)
+}
+
+func SecurityContextSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Description: description,
+		Optional:    true,
+		MaxItems:    1,
+		Elem:        securityContextSchema(true),
+	}
+}
+
+func ExpandSecurityContext(l []interface{}) (*v1.SecurityContext, error) {
+	if len(l) == 0 || l[0] == nil {
+		return nil, nil
+	}
+
+	return expandContainerSecurityContext(l)
+}
+
+func FlattenSecurityContext(in *v1.SecurityContext) []interface{} {
+	if in == nil {
+		return []interface{}{}
+	}
+
+	return flattenContainerSecurityContext(in)
+}
+
+func volumeDeviceFields() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:        schema.TypeString,
+			Description: "Name of the persistent volume claim of the pod to map",
+			Required:    true,
+		},
+		"device_path": {
+			Type:        schema.TypeString,
+			Description: "Path inside of the container that the device is mapped to",
+			Required:    true,
+		},
+	}
+}
+
+// VolumeDevicesSchema returns the schema of the block devices mapped into a container.
+func VolumeDevicesSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Description: description,
+		Optional:    true,
+		Elem: &schema.Resource{
+			Schema: volumeDeviceFields(),
+		},
+	}
+}
+
+func ExpandVolumeDevices(in []interface{}) []v1.VolumeDevice {
+	if len(in) == 0 {
+		return nil
+	}
+
+	result := make([]v1.VolumeDevice, 0, len(in))
+	for _, d := range in {
+		m, ok := d.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		result = append(result, v1.VolumeDevice{
+			Name:       m["name"].(string),
+			DevicePath: m["device_path"].(string),
+		})
+	}
+	return result
+}
+
+func FlattenVolumeDevices(in []v1.VolumeDevice) []interface{} {
+	result := make([]interface{}, 0, len(in))
+	for _, v := range in {
+		result = append(result, map[string]interface{}{
+			"name":        v.Name,
+			"device_path": v.DevicePath,
+		})
+	}
+	return result
+}
@@ -111,9 +111,9 @@ func namespacedMetadataSchemaIsTemplate(objectName string, generatableName, isTe
 			Optional:      true,
 			ForceNew:      true,
 			ValidateFunc:  utils.ValidateGenerateName,
-			ConflictsWith: []string{"metadata.name"},
+			ConflictsWith: []string{"metadata.0.name"},
 		}
-		fields["name"].ConflictsWith = []string{"metadata.generate_name"}
+		fields["name"].ConflictsWith = []string{"metadata.0.generate_name"}
 	}
 
 	return &schema.Schema{
@@ -193,6 +193,19 @@ func AppendPatchOps(keyPrefix, pathPrefix string, resourceData *schema.ResourceD
 	return ops
 }
 
+// AppendResourceVersionTestOp appends a test operation on the resource version the object had
+// when it was last read, the one the patch is computed against. The client applies the patch onto
+// the current object instead when it changed since, so that concurrent changes are not overwritten.
+func AppendResourceVersionTestOp(keyPrefix, pathPrefix string, resourceData *schema.ResourceData, ops []patch.PatchOperation) patch.PatchOperations {
+	if v, ok := resourceData.Get(keyPrefix + "resource_version").(string); ok && v != "" {
+		ops = append(ops, &patch.TestOperation{
+			Path:  pathPrefix + "resourceVersion",
+			Value: v,
+		})
+	}
+	return ops
+}
+
 func removeInternalKeys(m map[string]string, d map[string]interface{}) map[string]string {
 	for k := range m {
 		if isInternalKey(k) && !isKeyInMap(k, d) {
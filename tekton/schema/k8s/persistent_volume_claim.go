@@ -182,3 +182,76 @@ func flattenPersistentVolumeAccessModes(in []api.PersistentVolumeAccessMode) *sc
 	}
 	return schema.NewSet(schema.HashString, out)
 }
+
+func PersistentVolumeClaimTemplateSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Description: description,
+		Optional:    true,
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"metadata": {
+					Type:        schema.TypeList,
+					Description: "Labels and annotations of the claim created from the template.",
+					Optional:    true,
+					MaxItems:    1,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"labels": {
+								Type:         schema.TypeMap,
+								Description:  "Map of string keys and values that can be used to organize and categorize (scope and select) the claim.",
+								Optional:     true,
+								Elem:         &schema.Schema{Type: schema.TypeString},
+								ValidateFunc: utils.ValidateLabels,
+							},
+							"annotations": {
+								Type:         schema.TypeMap,
+								Description:  "An unstructured key value map stored with the claim that may be used to store arbitrary metadata.",
+								Optional:     true,
+								Elem:         &schema.Schema{Type: schema.TypeString},
+								ValidateFunc: utils.ValidateAnnotations,
+							},
+						},
+					},
+				},
+				"spec": PersistentVolumeClaimSpecSchema(),
+			},
+		},
+	}
+}
+
+func ExpandPersistentVolumeClaimTemplate(l []interface{}) (*v1.PersistentVolumeClaim, error) {
+	if len(l) == 0 || l[0] == nil {
+		return nil, nil
+	}
+	in := l[0].(map[string]interface{})
+	obj := &v1.PersistentVolumeClaim{}
+
+	if v, ok := in["metadata"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+		m := v[0].(map[string]interface{})
+		obj.Labels = utils.ExpandStringMap(m["labels"].(map[string]interface{}))
+		obj.Annotations = utils.ExpandStringMap(m["annotations"].(map[string]interface{}))
+	}
+	spec, err := ExpandPersistentVolumeClaimSpec(in["spec"].([]interface{}))
+	if err != nil {
+		return obj, err
+	}
+	obj.Spec = *spec
+	return obj, nil
+}
+
+func FlattenPersistentVolumeClaimTemplate(in *v1.PersistentVolumeClaim) []interface{} {
+	if in == nil {
+		return []interface{}{}
+	}
+	att := make(map[string]interface{})
+	if len(in.Labels) > 0 || len(in.Annotations) > 0 {
+		att["metadata"] = []interface{}{map[string]interface{}{
+			"labels":      utils.FlattenStringMap(in.Labels),
+			"annotations": utils.FlattenStringMap(in.Annotations),
+		}}
+	}
+	att["spec"] = FlattenPersistentVolumeClaimSpec(in.Spec)
+	return []interface{}{att}
+}
@@ -0,0 +1,34 @@
+package k8s
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	v1 "k8s.io/api/core/v1"
+)
+
+func ResourceRequirementsSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Description: description,
+		Optional:    true,
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: resourcesFieldV1(true),
+		},
+	}
+}
+
+func ExpandResourceRequirements(l []interface{}) (*v1.ResourceRequirements, error) {
+	if len(l) == 0 || l[0] == nil {
+		return nil, nil
+	}
+
+	return expandResourceRequirements(l)
+}
+
+func FlattenResourceRequirements(in *v1.ResourceRequirements) []interface{} {
+	if in == nil {
+		return []interface{}{}
+	}
+
+	return flattenResourceRequirements(*in)
+}
@@ -5,22 +5,368 @@ package k8s
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/rh01/terraform-provider-tekton/tekton/utils"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
+	v1 "k8s.io/api/core/v1"
 )
 
-func PodTemplateFields(owner string) map[string]*schema.Schema {
-	s := map[string]*schema.Schema{
-		"metadata": metadataSchema(owner, true),
-		"spec": {
-			Type:        schema.TypeList,
-			Description: fmt.Sprintf("Spec of the pods owned by the %s", owner),
-			Optional:    true,
-			MaxItems:    1,
-			Elem: &schema.Resource{
-				Schema: PodSpecFields(true, false),
+// supportedPodTemplateFields are the PodSpec fields Tekton lets users override through a pod template.
+var supportedPodTemplateFields = []string{
+	"affinity",
+	"automount_service_account_token",
+	"dns_policy",
+	"enable_service_links",
+	"host_aliases",
+	"host_network",
+	"image_pull_secrets",
+	"node_selector",
+	"priority_class_name",
+	"runtime_class_name",
+	"scheduler_name",
+	"security_context",
+}
+
+// PodTemplateFields describes the pod template used by Tekton to create the pods of a TaskRun.
+func PodTemplateFields() map[string]*schema.Schema {
+	podSpec := PodSpecFields(true, false)
+
+	s := make(map[string]*schema.Schema, len(supportedPodTemplateFields)+5)
+	for _, k := range supportedPodTemplateFields {
+		s[k] = podSpec[k]
+	}
+	// Tekton leaves these to the cluster defaults when they are not set.
+	s["scheduler_name"].Computed = false
+	s["image_pull_secrets"].Computed = false
+
+	s["dns_config"] = PodDnsConfigSchema()
+	s["toleration"] = TolerationSchema()
+	s["volume"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		Description: "List of volumes that can be mounted by containers belonging to the pod.",
+		Elem:        VolumeSchema(),
+	}
+	s["env"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		Description: "List of environment variables that can be provided to the containers belonging to the pod.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:        schema.TypeString,
+					Description: "Name of the environment variable. Must be a C_IDENTIFIER",
+					Required:    true,
+				},
+				"value": {
+					Type:        schema.TypeString,
+					Description: "Variable references $(VAR_NAME) are expanded using the previous defined environment variables in the container and any service environment variables.",
+					Optional:    true,
+				},
 			},
 		},
 	}
+
 	return s
 }
+
+func PodTemplateSchema(owner string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Description: fmt.Sprintf("PodTemplate holds pod specific configuration for the pods created by the %s", owner),
+		Optional:    true,
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: PodTemplateFields(),
+		},
+	}
+}
+
+func ExpandPodTemplate(l []interface{}) (*pod.Template, error) {
+	if len(l) == 0 || l[0] == nil {
+		return nil, nil
+	}
+
+	in := l[0].(map[string]interface{})
+	obj := &pod.Template{}
+
+	if v, ok := in["affinity"].([]interface{}); ok && len(v) > 0 {
+		obj.Affinity = ExpandAffinity(v)
+	}
+	if v, ok := in["automount_service_account_token"].(bool); ok {
+		obj.AutomountServiceAccountToken = utils.PtrToBool(v)
+	}
+	if v, ok := in["dns_policy"].(string); ok && v != "" {
+		obj.DNSPolicy = (*v1.DNSPolicy)(&v)
+	}
+	if v, ok := in["dns_config"].([]interface{}); ok && len(v) > 0 {
+		dnsConfig, err := ExpandPodDNSConfig(v)
+		if err != nil {
+			return obj, err
+		}
+		obj.DNSConfig = dnsConfig
+	}
+	if v, ok := in["enable_service_links"].(bool); ok {
+		obj.EnableServiceLinks = utils.PtrToBool(v)
+	}
+	if v, ok := in["env"].([]interface{}); ok {
+		obj.Env = expandPodTemplateEnv(v)
+	}
+	if v, ok := in["host_aliases"].([]interface{}); ok {
+		obj.HostAliases = expandHostAliases(v)
+	}
+	if v, ok := in["host_network"].(bool); ok {
+		obj.HostNetwork = v
+	}
+	if v, ok := in["image_pull_secrets"].([]interface{}); ok {
+		obj.ImagePullSecrets = expandLocalObjectReferenceArray(v)
+	}
+	if v, ok := in["node_selector"].(map[string]interface{}); ok && len(v) > 0 {
+		obj.NodeSelector = utils.ExpandStringMap(v)
+	}
+	if v, ok := in["priority_class_name"].(string); ok && v != "" {
+		obj.PriorityClassName = utils.PtrToString(v)
+	}
+	if v, ok := in["runtime_class_name"].(string); ok && v != "" {
+		obj.RuntimeClassName = utils.PtrToString(v)
+	}
+	if v, ok := in["scheduler_name"].(string); ok {
+		obj.SchedulerName = v
+	}
+	if v, ok := in["security_context"].([]interface{}); ok && len(v) > 0 {
+		sc, err := expandPodSecurityContext(v)
+		if err != nil {
+			return obj, err
+		}
+		obj.SecurityContext = sc
+	}
+	if v, ok := in["toleration"].([]interface{}); ok && len(v) > 0 {
+		tolerations, err := ExpandTolerations(v)
+		if err != nil {
+			return obj, err
+		}
+		obj.Tolerations = tolerations
+	}
+	if v, ok := in["volume"].([]interface{}); ok && len(v) > 0 {
+		volumes, err := ExpandVolumes(v)
+		if err != nil {
+			return obj, err
+		}
+		obj.Volumes = volumes
+	}
+
+	return obj, nil
+}
+
+func expandPodTemplateEnv(in []interface{}) []v1.EnvVar {
+	if len(in) == 0 {
+		return nil
+	}
+
+	result := make([]v1.EnvVar, 0, len(in))
+	for _, e := range in {
+		m, ok := e.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		result = append(result, v1.EnvVar{
+			Name:  m["name"].(string),
+			Value: m["value"].(string),
+		})
+	}
+
+	return result
+}
+
+func expandHostAliases(in []interface{}) []v1.HostAlias {
+	if len(in) == 0 {
+		return nil
+	}
+
+	result := make([]v1.HostAlias, 0, len(in))
+	for _, h := range in {
+		m, ok := h.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		result = append(result, v1.HostAlias{
+			IP:        m["ip"].(string),
+			Hostnames: utils.ExpandStringSlice(m["hostnames"].([]interface{})),
+		})
+	}
+
+	return result
+}
+
+func expandPodSecurityContext(l []interface{}) (*v1.PodSecurityContext, error) {
+	obj := &v1.PodSecurityContext{}
+	if len(l) == 0 || l[0] == nil {
+		return obj, nil
+	}
+
+	in := l[0].(map[string]interface{})
+
+	if v, ok := in["fs_group"].(string); ok && v != "" {
+		i, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return obj, err
+		}
+		obj.FSGroup = utils.PtrToInt64(i)
+	}
+	if v, ok := in["fs_group_change_policy"].(string); ok && v != "" {
+		policy := v1.PodFSGroupChangePolicy(v)
+		obj.FSGroupChangePolicy = &policy
+	}
+	if v, ok := in["run_as_group"].(string); ok && v != "" {
+		i, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return obj, err
+		}
+		obj.RunAsGroup = utils.PtrToInt64(i)
+	}
+	if v, ok := in["run_as_non_root"].(bool); ok && v {
+		obj.RunAsNonRoot = utils.PtrToBool(v)
+	}
+	if v, ok := in["run_as_user"].(string); ok && v != "" {
+		i, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return obj, err
+		}
+		obj.RunAsUser = utils.PtrToInt64(i)
+	}
+	if v, ok := in["seccomp_profile"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+		obj.SeccompProfile = expandSeccompProfile(v)
+	}
+	if v, ok := in["se_linux_options"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+		obj.SELinuxOptions = expandSeLinuxOptions(v)
+	}
+	if v, ok := in["supplemental_groups"].(*schema.Set); ok && v.Len() > 0 {
+		obj.SupplementalGroups = schemaSetToInt64Array(v)
+	}
+	if v, ok := in["sysctl"].([]interface{}); ok {
+		for _, s := range v {
+			m, ok := s.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			obj.Sysctls = append(obj.Sysctls, v1.Sysctl{
+				Name:  m["name"].(string),
+				Value: m["value"].(string),
+			})
+		}
+	}
+
+	return obj, nil
+}
+
+func FlattenPodTemplate(in *pod.Template) []interface{} {
+	if in == nil {
+		return []interface{}{}
+	}
+
+	att := make(map[string]interface{})
+
+	if in.Affinity != nil {
+		att["affinity"] = FlattenAffinity(in.Affinity)
+	}
+	if in.AutomountServiceAccountToken != nil {
+		att["automount_service_account_token"] = *in.AutomountServiceAccountToken
+	}
+	if in.DNSPolicy != nil {
+		att["dns_policy"] = string(*in.DNSPolicy)
+	}
+	if in.DNSConfig != nil {
+		att["dns_config"] = FlattenPodDNSConfig(in.DNSConfig)
+	}
+	if in.EnableServiceLinks != nil {
+		att["enable_service_links"] = *in.EnableServiceLinks
+	}
+	att["env"] = flattenPodTemplateEnv(in.Env)
+	att["host_aliases"] = flattenHostAliases(in.HostAliases)
+	att["host_network"] = in.HostNetwork
+	att["image_pull_secrets"] = flattenLocalObjectReferenceArray(in.ImagePullSecrets)
+	att["node_selector"] = in.NodeSelector
+	if in.PriorityClassName != nil {
+		att["priority_class_name"] = *in.PriorityClassName
+	}
+	if in.RuntimeClassName != nil {
+		att["runtime_class_name"] = *in.RuntimeClassName
+	}
+	att["scheduler_name"] = in.SchedulerName
+	if in.SecurityContext != nil {
+		att["security_context"] = flattenPodSecurityContext(in.SecurityContext)
+	}
+	att["toleration"] = FlattenTolerations(in.Tolerations)
+	att["volume"] = FlattenVolumes(in.Volumes)
+
+	return []interface{}{att}
+}
+
+func flattenPodTemplateEnv(in []v1.EnvVar) []interface{} {
+	result := make([]interface{}, 0, len(in))
+
+	for _, v := range in {
+		result = append(result, map[string]interface{}{
+			"name":  v.Name,
+			"value": v.Value,
+		})
+	}
+
+	return result
+}
+
+func flattenHostAliases(in []v1.HostAlias) []interface{} {
+	result := make([]interface{}, 0, len(in))
+
+	for _, v := range in {
+		result = append(result, map[string]interface{}{
+			"ip":        v.IP,
+			"hostnames": v.Hostnames,
+		})
+	}
+
+	return result
+}
+
+func flattenPodSecurityContext(in *v1.PodSecurityContext) []interface{} {
+	att := make(map[string]interface{})
+
+	if in.FSGroup != nil {
+		att["fs_group"] = strconv.FormatInt(*in.FSGroup, 10)
+	}
+	if in.FSGroupChangePolicy != nil {
+		att["fs_group_change_policy"] = string(*in.FSGroupChangePolicy)
+	}
+	if in.RunAsGroup != nil {
+		att["run_as_group"] = strconv.FormatInt(*in.RunAsGroup, 10)
+	}
+	if in.RunAsNonRoot != nil {
+		att["run_as_non_root"] = *in.RunAsNonRoot
+	}
+	if in.RunAsUser != nil {
+		att["run_as_user"] = strconv.FormatInt(*in.RunAsUser, 10)
+	}
+	if in.SeccompProfile != nil {
+		att["seccomp_profile"] = flattenSeccompProfile(in.SeccompProfile)
+	}
+	if in.SELinuxOptions != nil {
+		att["se_linux_options"] = flattenSeLinuxOptions(in.SELinuxOptions)
+	}
+	if len(in.SupplementalGroups) > 0 {
+		att["supplemental_groups"] = newInt64Set(schema.HashSchema(&schema.Schema{Type: schema.TypeInt}), in.SupplementalGroups)
+	}
+	if len(in.Sysctls) > 0 {
+		sysctls := make([]interface{}, 0, len(in.Sysctls))
+		for _, s := range in.Sysctls {
+			sysctls = append(sysctls, map[string]interface{}{
+				"name":  s.Name,
+				"value": s.Value,
+			})
+		}
+		att["sysctl"] = sysctls
+	}
+
+	return []interface{}{att}
+}
@@ -0,0 +1,97 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"k8s.io/apimachinery/pkg/api/equality"
+)
+
+func TestPodTemplateRoundTrip(t *testing.T) {
+	fields := map[string]*schema.Schema{
+		"pod_template": PodTemplateSchema("TaskRun"),
+	}
+
+	testCases := []struct {
+		PodTemplate map[string]interface{}
+		// ExpectedAutomount is the expanded automountServiceAccountToken, true unless it is disabled.
+		ExpectedAutomount bool
+	}{
+		{
+			PodTemplate: map[string]interface{}{
+				"node_selector": map[string]interface{}{"kubernetes.io/arch": "amd64"},
+			},
+			ExpectedAutomount: true,
+		},
+		{
+			PodTemplate: map[string]interface{}{
+				"automount_service_account_token": false,
+				"dns_policy":                      "None",
+				"dns_config": []interface{}{map[string]interface{}{
+					"nameservers": []interface{}{"10.0.0.10"},
+					"searches":    []interface{}{"ci.svc.cluster.local"},
+					"option":      []interface{}{map[string]interface{}{"name": "ndots", "value": "2"}},
+				}},
+				"enable_service_links": false,
+				"env":                  []interface{}{map[string]interface{}{"name": "HTTP_PROXY", "value": "http://proxy:3128"}},
+				"host_aliases":         []interface{}{map[string]interface{}{"ip": "10.0.0.20", "hostnames": []interface{}{"registry.local"}}},
+				"image_pull_secrets":   []interface{}{map[string]interface{}{"name": "registry"}},
+				"priority_class_name":  "ci",
+				"runtime_class_name":   "gvisor",
+				"scheduler_name":       "ci-scheduler",
+				"security_context": []interface{}{map[string]interface{}{
+					"fs_group":        "1000",
+					"run_as_non_root": true,
+					"run_as_user":     "1000",
+				}},
+				"toleration": []interface{}{map[string]interface{}{"key": "ci", "operator": "Exists", "effect": "NoSchedule"}},
+				"volume": []interface{}{map[string]interface{}{
+					"name":       "cache",
+					"config_map": []interface{}{map[string]interface{}{"name": "cache"}},
+				}},
+			},
+			ExpectedAutomount: false,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			raw := map[string]interface{}{"pod_template": []interface{}{tc.PodTemplate}}
+			expanded, err := ExpandPodTemplate(schema.TestResourceDataRaw(t, fields, raw).Get("pod_template").([]interface{}))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if expanded.AutomountServiceAccountToken == nil || *expanded.AutomountServiceAccountToken != tc.ExpectedAutomount {
+				t.Fatalf("Expected automountServiceAccountToken to be %t, given: %v", tc.ExpectedAutomount, expanded.AutomountServiceAccountToken)
+			}
+
+			resourceData := schema.TestResourceDataRaw(t, fields, map[string]interface{}{})
+			if err := resourceData.Set("pod_template", FlattenPodTemplate(expanded)); err != nil {
+				t.Fatal(err)
+			}
+			given, err := ExpandPodTemplate(resourceData.Get("pod_template").([]interface{}))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !equality.Semantic.DeepEqual(expanded, given) {
+				expectedJSON, _ := json.Marshal(expanded)
+				givenJSON, _ := json.Marshal(given)
+				t.Fatalf("Pod templates don't match.\nExpected: %s\nGiven:    %s\n", expectedJSON, givenJSON)
+			}
+
+			// The state read back doesn't differ from the configuration, defaults included.
+			resourceData.SetId("test")
+			diff, err := schema.InternalMap(fields).Diff(context.Background(), resourceData.State(), terraform.NewResourceConfigRaw(raw), nil, nil, true)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff != nil && !diff.Empty() {
+				t.Fatalf("Expected no diff, given: %#v", diff.Attributes)
+			}
+		})
+	}
+}
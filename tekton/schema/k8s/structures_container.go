@@ -45,16 +45,35 @@ func flattenContainerSecurityContext(in *v1.SecurityContext) []interface{} {
 	if in.RunAsUser != nil {
 		att["run_as_user"] = strconv.Itoa(int(*in.RunAsUser))
 	}
-	// if in.SeccompProfile != nil {
-	// 	att["seccomp_profile"] = flattenSeccompProfile(in.SeccompProfile)
-	// }
-	// if in.SELinuxOptions != nil {
-	// 	att["se_linux_options"] = flattenSeLinuxOptions(in.SELinuxOptions)
-	// }
+	if in.SeccompProfile != nil {
+		att["seccomp_profile"] = flattenSeccompProfile(in.SeccompProfile)
+	}
+	if in.SELinuxOptions != nil {
+		att["se_linux_options"] = flattenSeLinuxOptions(in.SELinuxOptions)
+	}
 	return []interface{}{att}
 
 }
 
+func flattenSeccompProfile(in *v1.SeccompProfile) []interface{} {
+	att := map[string]interface{}{
+		"type": string(in.Type),
+	}
+	if in.LocalhostProfile != nil {
+		att["localhost_profile"] = *in.LocalhostProfile
+	}
+	return []interface{}{att}
+}
+
+func flattenSeLinuxOptions(in *v1.SELinuxOptions) []interface{} {
+	return []interface{}{map[string]interface{}{
+		"user":  in.User,
+		"role":  in.Role,
+		"type":  in.Type,
+		"level": in.Level,
+	}}
+}
+
 func flattenSecurityCapabilities(in *v1.Capabilities) []interface{} {
 	att := make(map[string]interface{})
 
@@ -628,16 +647,37 @@ func expandContainerSecurityContext(l []interface{}) (*v1.SecurityContext, error
 		}
 		obj.RunAsUser = ptrToInt64(int64(i))
 	}
-	// if v, ok := in["seccomp_profile"].([]interface{}); ok && len(v) > 0 {
-	// 	obj.SeccompProfile = expandSeccompProfile(v)
-	// }
-	// if v, ok := in["se_linux_options"].([]interface{}); ok && len(v) > 0 {
-	// 	obj.SELinuxOptions = expandSeLinuxOptions(v)
-	// }
+	if v, ok := in["seccomp_profile"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+		obj.SeccompProfile = expandSeccompProfile(v)
+	}
+	if v, ok := in["se_linux_options"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+		obj.SELinuxOptions = expandSeLinuxOptions(v)
+	}
 
 	return &obj, nil
 }
 
+func expandSeccompProfile(l []interface{}) *v1.SeccompProfile {
+	in := l[0].(map[string]interface{})
+	obj := &v1.SeccompProfile{
+		Type: v1.SeccompProfileType(in["type"].(string)),
+	}
+	if v, ok := in["localhost_profile"].(string); ok && v != "" {
+		obj.LocalhostProfile = ptrToString(v)
+	}
+	return obj
+}
+
+func expandSeLinuxOptions(l []interface{}) *v1.SELinuxOptions {
+	in := l[0].(map[string]interface{})
+	return &v1.SELinuxOptions{
+		User:  in["user"].(string),
+		Role:  in["role"].(string),
+		Type:  in["type"].(string),
+		Level: in["level"].(string),
+	}
+}
+
 func expandCapabilitySlice(s []interface{}) []v1.Capability {
 	result := make([]v1.Capability, len(s), len(s))
 	for k, v := range s {
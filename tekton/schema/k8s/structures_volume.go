@@ -0,0 +1,736 @@
+package k8s
+
+import (
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/rh01/terraform-provider-tekton/tekton/utils"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// supportedVolumeSources lists the volume sources handled by ExpandVolumes and FlattenVolumes.
+var supportedVolumeSources = []string{
+	"name",
+	"config_map",
+	"csi",
+	"downward_api",
+	"empty_dir",
+	"git_repo",
+	"host_path",
+	"nfs",
+	"persistent_volume_claim",
+	"projected",
+	"secret",
+}
+
+// VolumeSchema returns the pod volume schema restricted to the supported volume sources.
+func VolumeSchema() *schema.Resource {
+	v := volumeSchema(true)
+	fields := make(map[string]*schema.Schema, len(supportedVolumeSources))
+	for _, k := range supportedVolumeSources {
+		fields[k] = v.Schema[k]
+	}
+	v.Schema = fields
+	return v
+}
+
+// Expanders
+
+func ExpandVolumes(volumes []interface{}) ([]v1.Volume, error) {
+	if len(volumes) == 0 {
+		return nil, nil
+	}
+	vl := make([]v1.Volume, len(volumes))
+	for i, c := range volumes {
+		m, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if v, ok := m["name"].(string); ok {
+			vl[i].Name = v
+		}
+		if v, ok := m["config_map"].([]interface{}); ok && len(v) > 0 {
+			cfm, err := ExpandConfigMapVolumeSource(v)
+			if err != nil {
+				return vl, err
+			}
+			vl[i].ConfigMap = cfm
+		}
+		if v, ok := m["csi"].([]interface{}); ok && len(v) > 0 {
+			vl[i].CSI = ExpandCSIVolumeSource(v)
+		}
+		if v, ok := m["downward_api"].([]interface{}); ok && len(v) > 0 {
+			dapi, err := expandDownwardAPIVolumeSource(v)
+			if err != nil {
+				return vl, err
+			}
+			vl[i].DownwardAPI = dapi
+		}
+		if v, ok := m["empty_dir"].([]interface{}); ok && len(v) > 0 {
+			ed, err := ExpandEmptyDirVolumeSource(v)
+			if err != nil {
+				return vl, err
+			}
+			vl[i].EmptyDir = ed
+		}
+		if v, ok := m["git_repo"].([]interface{}); ok && len(v) > 0 {
+			vl[i].GitRepo = expandGitRepoVolumeSource(v)
+		}
+		if v, ok := m["host_path"].([]interface{}); ok && len(v) > 0 {
+			vl[i].HostPath = expandHostPathVolumeSource(v)
+		}
+		if v, ok := m["nfs"].([]interface{}); ok && len(v) > 0 {
+			vl[i].NFS = expandNFSVolumeSource(v)
+		}
+		if v, ok := m["persistent_volume_claim"].([]interface{}); ok && len(v) > 0 {
+			vl[i].PersistentVolumeClaim = ExpandPersistentVolumeClaimVolumeSource(v)
+		}
+		if v, ok := m["projected"].([]interface{}); ok && len(v) > 0 {
+			pj, err := ExpandProjectedVolumeSource(v)
+			if err != nil {
+				return vl, err
+			}
+			vl[i].Projected = pj
+		}
+		if v, ok := m["secret"].([]interface{}); ok && len(v) > 0 {
+			sc, err := ExpandSecretVolumeSource(v)
+			if err != nil {
+				return vl, err
+			}
+			vl[i].Secret = sc
+		}
+	}
+	return vl, nil
+}
+
+func expandModeBits(v interface{}) (*int32, error) {
+	s, ok := v.(string)
+	if !ok || s == "" {
+		return nil, nil
+	}
+	mode, err := strconv.ParseInt(s, 8, 32)
+	if err != nil {
+		return nil, err
+	}
+	return utils.PtrToInt32(int32(mode)), nil
+}
+
+func expandKeyToPaths(in []interface{}) ([]v1.KeyToPath, error) {
+	if len(in) == 0 {
+		return nil, nil
+	}
+	keys := make([]v1.KeyToPath, len(in))
+	for i, c := range in {
+		p, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if v, ok := p["key"].(string); ok {
+			keys[i].Key = v
+		}
+		if v, ok := p["path"].(string); ok {
+			keys[i].Path = v
+		}
+		mode, err := expandModeBits(p["mode"])
+		if err != nil {
+			return keys, err
+		}
+		keys[i].Mode = mode
+	}
+	return keys, nil
+}
+
+func ExpandConfigMapVolumeSource(l []interface{}) (*v1.ConfigMapVolumeSource, error) {
+	obj := &v1.ConfigMapVolumeSource{}
+	if len(l) == 0 || l[0] == nil {
+		return obj, nil
+	}
+	in := l[0].(map[string]interface{})
+
+	mode, err := expandModeBits(in["default_mode"])
+	if err != nil {
+		return obj, err
+	}
+	obj.DefaultMode = mode
+	if v, ok := in["name"].(string); ok {
+		obj.Name = v
+	}
+	if v, ok := in["optional"].(bool); ok && v {
+		obj.Optional = utils.PtrToBool(v)
+	}
+	if v, ok := in["items"].([]interface{}); ok && len(v) > 0 {
+		obj.Items, err = expandKeyToPaths(v)
+		if err != nil {
+			return obj, err
+		}
+	}
+	return obj, nil
+}
+
+func ExpandSecretVolumeSource(l []interface{}) (*v1.SecretVolumeSource, error) {
+	obj := &v1.SecretVolumeSource{}
+	if len(l) == 0 || l[0] == nil {
+		return obj, nil
+	}
+	in := l[0].(map[string]interface{})
+
+	mode, err := expandModeBits(in["default_mode"])
+	if err != nil {
+		return obj, err
+	}
+	obj.DefaultMode = mode
+	if v, ok := in["secret_name"].(string); ok {
+		obj.SecretName = v
+	}
+	if v, ok := in["optional"].(bool); ok && v {
+		obj.Optional = utils.PtrToBool(v)
+	}
+	if v, ok := in["items"].([]interface{}); ok && len(v) > 0 {
+		obj.Items, err = expandKeyToPaths(v)
+		if err != nil {
+			return obj, err
+		}
+	}
+	return obj, nil
+}
+
+func ExpandEmptyDirVolumeSource(l []interface{}) (*v1.EmptyDirVolumeSource, error) {
+	obj := &v1.EmptyDirVolumeSource{}
+	if len(l) == 0 || l[0] == nil {
+		return obj, nil
+	}
+	in := l[0].(map[string]interface{})
+
+	if v, ok := in["medium"].(string); ok {
+		obj.Medium = v1.StorageMedium(v)
+	}
+	if v, ok := in["size_limit"].(string); ok && v != "" {
+		q, err := resource.ParseQuantity(v)
+		if err != nil {
+			return obj, err
+		}
+		obj.SizeLimit = &q
+	}
+	return obj, nil
+}
+
+func ExpandPersistentVolumeClaimVolumeSource(l []interface{}) *v1.PersistentVolumeClaimVolumeSource {
+	obj := &v1.PersistentVolumeClaimVolumeSource{}
+	if len(l) == 0 || l[0] == nil {
+		return obj
+	}
+	in := l[0].(map[string]interface{})
+
+	if v, ok := in["claim_name"].(string); ok {
+		obj.ClaimName = v
+	}
+	if v, ok := in["read_only"].(bool); ok {
+		obj.ReadOnly = v
+	}
+	return obj
+}
+
+func ExpandCSIVolumeSource(l []interface{}) *v1.CSIVolumeSource {
+	obj := &v1.CSIVolumeSource{}
+	if len(l) == 0 || l[0] == nil {
+		return obj
+	}
+	in := l[0].(map[string]interface{})
+
+	if v, ok := in["driver"].(string); ok {
+		obj.Driver = v
+	}
+	if v, ok := in["fs_type"].(string); ok && v != "" {
+		obj.FSType = utils.PtrToString(v)
+	}
+	if v, ok := in["read_only"].(bool); ok && v {
+		obj.ReadOnly = utils.PtrToBool(v)
+	}
+	if v, ok := in["volume_attributes"].(map[string]interface{}); ok && len(v) > 0 {
+		obj.VolumeAttributes = utils.ExpandStringMap(v)
+	}
+	if v, ok := in["node_publish_secret_ref"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+		ref := v[0].(map[string]interface{})
+		obj.NodePublishSecretRef = &v1.LocalObjectReference{
+			Name: ref["name"].(string),
+		}
+	}
+	return obj
+}
+
+func expandDownwardAPIVolumeFiles(in []interface{}) ([]v1.DownwardAPIVolumeFile, error) {
+	if len(in) == 0 {
+		return nil, nil
+	}
+	files := make([]v1.DownwardAPIVolumeFile, len(in))
+	for i, c := range in {
+		p, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if v, ok := p["path"].(string); ok {
+			files[i].Path = v
+		}
+		mode, err := expandModeBits(p["mode"])
+		if err != nil {
+			return files, err
+		}
+		files[i].Mode = mode
+		if v, ok := p["field_ref"].([]interface{}); ok && len(v) > 0 {
+			files[i].FieldRef, err = expandFieldRef(v)
+			if err != nil {
+				return files, err
+			}
+		}
+		if v, ok := p["resource_field_ref"].([]interface{}); ok && len(v) > 0 {
+			files[i].ResourceFieldRef, err = expandResourceFieldRef(v)
+			if err != nil {
+				return files, err
+			}
+		}
+	}
+	return files, nil
+}
+
+func expandDownwardAPIVolumeSource(l []interface{}) (*v1.DownwardAPIVolumeSource, error) {
+	obj := &v1.DownwardAPIVolumeSource{}
+	if len(l) == 0 || l[0] == nil {
+		return obj, nil
+	}
+	in := l[0].(map[string]interface{})
+
+	mode, err := expandModeBits(in["default_mode"])
+	if err != nil {
+		return obj, err
+	}
+	obj.DefaultMode = mode
+	if v, ok := in["items"].([]interface{}); ok && len(v) > 0 {
+		obj.Items, err = expandDownwardAPIVolumeFiles(v)
+		if err != nil {
+			return obj, err
+		}
+	}
+	return obj, nil
+}
+
+func expandGitRepoVolumeSource(l []interface{}) *v1.GitRepoVolumeSource {
+	obj := &v1.GitRepoVolumeSource{}
+	if len(l) == 0 || l[0] == nil {
+		return obj
+	}
+	in := l[0].(map[string]interface{})
+
+	if v, ok := in["directory"].(string); ok {
+		obj.Directory = v
+	}
+	if v, ok := in["repository"].(string); ok {
+		obj.Repository = v
+	}
+	if v, ok := in["revision"].(string); ok {
+		obj.Revision = v
+	}
+	return obj
+}
+
+func expandHostPathVolumeSource(l []interface{}) *v1.HostPathVolumeSource {
+	obj := &v1.HostPathVolumeSource{}
+	if len(l) == 0 || l[0] == nil {
+		return obj
+	}
+	in := l[0].(map[string]interface{})
+
+	if v, ok := in["path"].(string); ok {
+		obj.Path = v
+	}
+	if v, ok := in["type"].(string); ok && v != "" {
+		t := v1.HostPathType(v)
+		obj.Type = &t
+	}
+	return obj
+}
+
+func expandNFSVolumeSource(l []interface{}) *v1.NFSVolumeSource {
+	obj := &v1.NFSVolumeSource{}
+	if len(l) == 0 || l[0] == nil {
+		return obj
+	}
+	in := l[0].(map[string]interface{})
+
+	if v, ok := in["server"].(string); ok {
+		obj.Server = v
+	}
+	if v, ok := in["path"].(string); ok {
+		obj.Path = v
+	}
+	if v, ok := in["read_only"].(bool); ok {
+		obj.ReadOnly = v
+	}
+	return obj
+}
+
+func ExpandProjectedVolumeSource(l []interface{}) (*v1.ProjectedVolumeSource, error) {
+	obj := &v1.ProjectedVolumeSource{}
+	if len(l) == 0 || l[0] == nil {
+		return obj, nil
+	}
+	in := l[0].(map[string]interface{})
+
+	mode, err := expandModeBits(in["default_mode"])
+	if err != nil {
+		return obj, err
+	}
+	obj.DefaultMode = mode
+
+	sources, _ := in["sources"].([]interface{})
+	for _, s := range sources {
+		src, ok := s.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if v, ok := src["secret"].([]interface{}); ok {
+			for _, e := range v {
+				p, err := expandProjectedSecret(e)
+				if err != nil {
+					return obj, err
+				}
+				obj.Sources = append(obj.Sources, v1.VolumeProjection{Secret: p})
+			}
+		}
+		if v, ok := src["config_map"].([]interface{}); ok {
+			for _, e := range v {
+				p, err := expandProjectedConfigMap(e)
+				if err != nil {
+					return obj, err
+				}
+				obj.Sources = append(obj.Sources, v1.VolumeProjection{ConfigMap: p})
+			}
+		}
+		if v, ok := src["downward_api"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+			p := &v1.DownwardAPIProjection{}
+			if items, ok := v[0].(map[string]interface{})["items"].([]interface{}); ok {
+				p.Items, err = expandDownwardAPIVolumeFiles(items)
+				if err != nil {
+					return obj, err
+				}
+			}
+			obj.Sources = append(obj.Sources, v1.VolumeProjection{DownwardAPI: p})
+		}
+		if v, ok := src["service_account_token"].([]interface{}); ok && len(v) > 0 && v[0] != nil {
+			sat := v[0].(map[string]interface{})
+			p := &v1.ServiceAccountTokenProjection{
+				Audience: sat["audience"].(string),
+				Path:     sat["path"].(string),
+			}
+			if e, ok := sat["expiration_seconds"].(int); ok && e > 0 {
+				p.ExpirationSeconds = utils.PtrToInt64(int64(e))
+			}
+			obj.Sources = append(obj.Sources, v1.VolumeProjection{ServiceAccountToken: p})
+		}
+	}
+	return obj, nil
+}
+
+func expandProjectedSecret(e interface{}) (*v1.SecretProjection, error) {
+	obj := &v1.SecretProjection{}
+	in, ok := e.(map[string]interface{})
+	if !ok {
+		return obj, nil
+	}
+	if v, ok := in["name"].(string); ok {
+		obj.Name = v
+	}
+	if v, ok := in["optional"].(bool); ok && v {
+		obj.Optional = utils.PtrToBool(v)
+	}
+	if v, ok := in["items"].([]interface{}); ok && len(v) > 0 {
+		var err error
+		obj.Items, err = expandKeyToPaths(v)
+		if err != nil {
+			return obj, err
+		}
+	}
+	return obj, nil
+}
+
+func expandProjectedConfigMap(e interface{}) (*v1.ConfigMapProjection, error) {
+	obj := &v1.ConfigMapProjection{}
+	in, ok := e.(map[string]interface{})
+	if !ok {
+		return obj, nil
+	}
+	if v, ok := in["name"].(string); ok {
+		obj.Name = v
+	}
+	if v, ok := in["optional"].(bool); ok && v {
+		obj.Optional = utils.PtrToBool(v)
+	}
+	if v, ok := in["items"].([]interface{}); ok && len(v) > 0 {
+		var err error
+		obj.Items, err = expandKeyToPaths(v)
+		if err != nil {
+			return obj, err
+		}
+	}
+	return obj, nil
+}
+
+// Flatteners
+
+func FlattenVolumes(in []v1.Volume) []interface{} {
+	att := make([]interface{}, len(in))
+	for i, v := range in {
+		obj := map[string]interface{}{}
+
+		if v.Name != "" {
+			obj["name"] = v.Name
+		}
+		if v.ConfigMap != nil {
+			obj["config_map"] = FlattenConfigMapVolumeSource(v.ConfigMap)
+		}
+		if v.CSI != nil {
+			obj["csi"] = FlattenCSIVolumeSource(v.CSI)
+		}
+		if v.DownwardAPI != nil {
+			obj["downward_api"] = flattenDownwardAPIVolumeSource(v.DownwardAPI)
+		}
+		if v.EmptyDir != nil {
+			obj["empty_dir"] = FlattenEmptyDirVolumeSource(v.EmptyDir)
+		}
+		if v.GitRepo != nil {
+			obj["git_repo"] = flattenGitRepoVolumeSource(v.GitRepo)
+		}
+		if v.HostPath != nil {
+			obj["host_path"] = flattenHostPathVolumeSource(v.HostPath)
+		}
+		if v.NFS != nil {
+			obj["nfs"] = flattenNFSVolumeSource(v.NFS)
+		}
+		if v.PersistentVolumeClaim != nil {
+			obj["persistent_volume_claim"] = FlattenPersistentVolumeClaimVolumeSource(v.PersistentVolumeClaim)
+		}
+		if v.Projected != nil {
+			obj["projected"] = FlattenProjectedVolumeSource(v.Projected)
+		}
+		if v.Secret != nil {
+			obj["secret"] = FlattenSecretVolumeSource(v.Secret)
+		}
+		att[i] = obj
+	}
+	return att
+}
+
+func flattenModeBits(in *int32) string {
+	return "0" + strconv.FormatInt(int64(*in), 8)
+}
+
+func flattenKeyToPaths(in []v1.KeyToPath) []interface{} {
+	att := make([]interface{}, len(in))
+	for i, v := range in {
+		m := map[string]interface{}{}
+		if v.Key != "" {
+			m["key"] = v.Key
+		}
+		if v.Mode != nil {
+			m["mode"] = flattenModeBits(v.Mode)
+		}
+		if v.Path != "" {
+			m["path"] = v.Path
+		}
+		att[i] = m
+	}
+	return att
+}
+
+func FlattenConfigMapVolumeSource(in *v1.ConfigMapVolumeSource) []interface{} {
+	att := make(map[string]interface{})
+	if in.DefaultMode != nil {
+		att["default_mode"] = flattenModeBits(in.DefaultMode)
+	}
+	att["name"] = in.Name
+	if len(in.Items) > 0 {
+		att["items"] = flattenKeyToPaths(in.Items)
+	}
+	if in.Optional != nil {
+		att["optional"] = *in.Optional
+	}
+	return []interface{}{att}
+}
+
+func FlattenSecretVolumeSource(in *v1.SecretVolumeSource) []interface{} {
+	att := make(map[string]interface{})
+	if in.DefaultMode != nil {
+		att["default_mode"] = flattenModeBits(in.DefaultMode)
+	}
+	if in.SecretName != "" {
+		att["secret_name"] = in.SecretName
+	}
+	if len(in.Items) > 0 {
+		att["items"] = flattenKeyToPaths(in.Items)
+	}
+	if in.Optional != nil {
+		att["optional"] = *in.Optional
+	}
+	return []interface{}{att}
+}
+
+func FlattenEmptyDirVolumeSource(in *v1.EmptyDirVolumeSource) []interface{} {
+	att := make(map[string]interface{})
+	att["medium"] = string(in.Medium)
+	if in.SizeLimit != nil {
+		att["size_limit"] = in.SizeLimit.String()
+	}
+	return []interface{}{att}
+}
+
+func FlattenPersistentVolumeClaimVolumeSource(in *v1.PersistentVolumeClaimVolumeSource) []interface{} {
+	att := make(map[string]interface{})
+	att["claim_name"] = in.ClaimName
+	if in.ReadOnly {
+		att["read_only"] = in.ReadOnly
+	}
+	return []interface{}{att}
+}
+
+func FlattenCSIVolumeSource(in *v1.CSIVolumeSource) []interface{} {
+	att := make(map[string]interface{})
+	att["driver"] = in.Driver
+	if in.FSType != nil {
+		att["fs_type"] = *in.FSType
+	}
+	if in.ReadOnly != nil {
+		att["read_only"] = *in.ReadOnly
+	}
+	if len(in.VolumeAttributes) > 0 {
+		att["volume_attributes"] = utils.FlattenStringMap(in.VolumeAttributes)
+	}
+	if in.NodePublishSecretRef != nil {
+		att["node_publish_secret_ref"] = []interface{}{
+			map[string]interface{}{"name": in.NodePublishSecretRef.Name},
+		}
+	}
+	return []interface{}{att}
+}
+
+func flattenDownwardAPIVolumeFiles(in []v1.DownwardAPIVolumeFile) []interface{} {
+	att := make([]interface{}, len(in))
+	for i, v := range in {
+		m := map[string]interface{}{}
+		if v.FieldRef != nil {
+			m["field_ref"] = flattenObjectFieldSelector(v.FieldRef)
+		}
+		if v.Mode != nil {
+			m["mode"] = flattenModeBits(v.Mode)
+		}
+		if v.Path != "" {
+			m["path"] = v.Path
+		}
+		if v.ResourceFieldRef != nil {
+			m["resource_field_ref"] = flattenResourceFieldSelector(v.ResourceFieldRef)
+		}
+		att[i] = m
+	}
+	return att
+}
+
+func flattenDownwardAPIVolumeSource(in *v1.DownwardAPIVolumeSource) []interface{} {
+	att := make(map[string]interface{})
+	if in.DefaultMode != nil {
+		att["default_mode"] = flattenModeBits(in.DefaultMode)
+	}
+	if len(in.Items) > 0 {
+		att["items"] = flattenDownwardAPIVolumeFiles(in.Items)
+	}
+	return []interface{}{att}
+}
+
+func flattenGitRepoVolumeSource(in *v1.GitRepoVolumeSource) []interface{} {
+	att := make(map[string]interface{})
+	if in.Directory != "" {
+		att["directory"] = in.Directory
+	}
+	att["repository"] = in.Repository
+	if in.Revision != "" {
+		att["revision"] = in.Revision
+	}
+	return []interface{}{att}
+}
+
+func flattenHostPathVolumeSource(in *v1.HostPathVolumeSource) []interface{} {
+	att := make(map[string]interface{})
+	att["path"] = in.Path
+	if in.Type != nil {
+		att["type"] = string(*in.Type)
+	}
+	return []interface{}{att}
+}
+
+func flattenNFSVolumeSource(in *v1.NFSVolumeSource) []interface{} {
+	att := make(map[string]interface{})
+	att["server"] = in.Server
+	att["path"] = in.Path
+	if in.ReadOnly {
+		att["read_only"] = in.ReadOnly
+	}
+	return []interface{}{att}
+}
+
+func FlattenProjectedVolumeSource(in *v1.ProjectedVolumeSource) []interface{} {
+	att := make(map[string]interface{})
+	if in.DefaultMode != nil {
+		att["default_mode"] = flattenModeBits(in.DefaultMode)
+	}
+	sources := make([]interface{}, 0, len(in.Sources))
+	for _, src := range in.Sources {
+		s := make(map[string]interface{})
+		if src.Secret != nil {
+			s["secret"] = []interface{}{flattenProjectedSecret(src.Secret)}
+		}
+		if src.ConfigMap != nil {
+			s["config_map"] = []interface{}{flattenProjectedConfigMap(src.ConfigMap)}
+		}
+		if src.DownwardAPI != nil {
+			s["downward_api"] = []interface{}{
+				map[string]interface{}{"items": flattenDownwardAPIVolumeFiles(src.DownwardAPI.Items)},
+			}
+		}
+		if src.ServiceAccountToken != nil {
+			sat := map[string]interface{}{
+				"audience": src.ServiceAccountToken.Audience,
+				"path":     src.ServiceAccountToken.Path,
+			}
+			if src.ServiceAccountToken.ExpirationSeconds != nil {
+				sat["expiration_seconds"] = int(*src.ServiceAccountToken.ExpirationSeconds)
+			}
+			s["service_account_token"] = []interface{}{sat}
+		}
+		sources = append(sources, s)
+	}
+	att["sources"] = sources
+	return []interface{}{att}
+}
+
+func flattenProjectedSecret(in *v1.SecretProjection) map[string]interface{} {
+	att := make(map[string]interface{})
+	att["name"] = in.Name
+	if len(in.Items) > 0 {
+		att["items"] = flattenKeyToPaths(in.Items)
+	}
+	if in.Optional != nil {
+		att["optional"] = *in.Optional
+	}
+	return att
+}
+
+func flattenProjectedConfigMap(in *v1.ConfigMapProjection) map[string]interface{} {
+	att := make(map[string]interface{})
+	att["name"] = in.Name
+	if len(in.Items) > 0 {
+		att["items"] = flattenKeyToPaths(in.Items)
+	}
+	if in.Optional != nil {
+		att["optional"] = *in.Optional
+	}
+	return att
+}
@@ -0,0 +1,62 @@
+package pipeline
+
+import (
+	"fmt"
+	"regexp"
+
+	tektonapiv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+)
+
+var (
+	// pipelineTaskStatusRe matches $(tasks.<name>.status), the execution status of a single pipeline task.
+	pipelineTaskStatusRe = regexp.MustCompile(`\$\(tasks\.([^.)]+)\.status\)`)
+	// aggregateTaskStatusRe matches $(tasks.status), the aggregate execution status of all pipeline tasks.
+	aggregateTaskStatusRe = regexp.MustCompile(`\$\(tasks\.status\)`)
+)
+
+// validateTaskStatusReferences checks the task status variables at plan time, the same way
+// the Tekton webhook does on admission: they are only available to finally tasks, and
+// $(tasks.<name>.status) must name one of the pipeline tasks.
+func validateTaskStatusReferences(tasks, finally []tektonapiv1.PipelineTask) error {
+	names := make(map[string]bool, len(tasks))
+	for _, t := range tasks {
+		names[t.Name] = true
+	}
+
+	for _, t := range tasks {
+		for _, s := range pipelineTaskStrings(t) {
+			if aggregateTaskStatusRe.MatchString(s) || pipelineTaskStatusRe.MatchString(s) {
+				return fmt.Errorf("pipeline task %q: task status variables such as %q can only be used in finally tasks", t.Name, s)
+			}
+		}
+	}
+
+	for _, t := range finally {
+		for _, s := range pipelineTaskStrings(t) {
+			for _, m := range pipelineTaskStatusRe.FindAllStringSubmatch(s, -1) {
+				if !names[m[1]] {
+					return fmt.Errorf("finally task %q: %q refers to %q, which is not one of the pipeline tasks", t.Name, m[0], m[1])
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// pipelineTaskStrings returns every string of a pipeline task that may hold variable references.
+func pipelineTaskStrings(t tektonapiv1.PipelineTask) []string {
+	var result []string
+	for _, p := range t.Params {
+		result = append(result, p.Value.StringVal)
+		result = append(result, p.Value.ArrayVal...)
+		for _, v := range p.Value.ObjectVal {
+			result = append(result, v)
+		}
+	}
+	for _, w := range t.When {
+		result = append(result, w.Input)
+		result = append(result, w.Values...)
+	}
+	return result
+}
@@ -0,0 +1,103 @@
+package pipeline
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tektonapiv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+)
+
+func TestValidateTaskStatusReferences(t *testing.T) {
+	stringParam := func(value string) tektonapiv1.Param {
+		return tektonapiv1.Param{
+			Name:  "status",
+			Value: tektonapiv1.ParamValue{Type: tektonapiv1.ParamTypeString, StringVal: value},
+		}
+	}
+	tasks := []tektonapiv1.PipelineTask{
+		{Name: "build"},
+		{Name: "test"},
+	}
+
+	testCases := []struct {
+		Tasks         []tektonapiv1.PipelineTask
+		Finally       []tektonapiv1.PipelineTask
+		ExpectedError string
+	}{
+		{
+			Tasks: tasks,
+			Finally: []tektonapiv1.PipelineTask{
+				{Name: "notify", Params: []tektonapiv1.Param{stringParam("$(tasks.build.status)")}},
+			},
+		},
+		{
+			Tasks: tasks,
+			Finally: []tektonapiv1.PipelineTask{
+				{Name: "notify", When: tektonapiv1.WhenExpressions{
+					{Input: "$(tasks.test.status)", Operator: "in", Values: []string{"Failed"}},
+				}},
+			},
+		},
+		{
+			Tasks: tasks,
+			Finally: []tektonapiv1.PipelineTask{
+				{Name: "notify", Params: []tektonapiv1.Param{stringParam("$(tasks.status)")}},
+			},
+		},
+		{
+			Tasks: tasks,
+			Finally: []tektonapiv1.PipelineTask{
+				{Name: "notify", Params: []tektonapiv1.Param{stringParam("$(tasks.deploy.status)")}},
+			},
+			ExpectedError: `refers to "deploy", which is not one of the pipeline tasks`,
+		},
+		{
+			Tasks: tasks,
+			Finally: []tektonapiv1.PipelineTask{
+				{Name: "notify", Params: []tektonapiv1.Param{{
+					Name:  "statuses",
+					Value: tektonapiv1.ParamValue{Type: tektonapiv1.ParamTypeArray, ArrayVal: []string{"$(tasks.build.status)", "$(tasks.notify.status)"}},
+				}}},
+			},
+			ExpectedError: `refers to "notify", which is not one of the pipeline tasks`,
+		},
+		{
+			Tasks: []tektonapiv1.PipelineTask{
+				{Name: "build"},
+				{Name: "test", Params: []tektonapiv1.Param{stringParam("$(tasks.build.status)")}},
+			},
+			ExpectedError: `pipeline task "test": task status variables such as "$(tasks.build.status)" can only be used in finally tasks`,
+		},
+		{
+			Tasks: []tektonapiv1.PipelineTask{
+				{Name: "build"},
+				{Name: "test", When: tektonapiv1.WhenExpressions{
+					{Input: "$(tasks.status)", Operator: "notin", Values: []string{"Failed"}},
+				}},
+			},
+			ExpectedError: `pipeline task "test": task status variables such as "$(tasks.status)" can only be used in finally tasks`,
+		},
+		{
+			Tasks: tasks,
+			Finally: []tektonapiv1.PipelineTask{
+				{Name: "notify", Params: []tektonapiv1.Param{stringParam("$(tasks.build.results.digest)")}},
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			err := validateTaskStatusReferences(tc.Tasks, tc.Finally)
+			if tc.ExpectedError == "" {
+				if err != nil {
+					t.Fatalf("Expected no error, given: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.ExpectedError) {
+				t.Fatalf("Expected an error containing %q, given: %v", tc.ExpectedError, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package pipeline
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/rh01/terraform-provider-tekton/tekton/utils"
+	tektonapiv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+)
+
+func tektonMatrixFields() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"params": {
+			Type:        schema.TypeList,
+			Description: "Params is a list of parameters used to fan out the pipeline task. Each combination of their values runs the task once.",
+			Optional:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:        schema.TypeString,
+						Description: "Name of the parameter.",
+						Required:    true,
+					},
+					"value": {
+						Type:        schema.TypeList,
+						Description: "Values of the parameter to fan out on.",
+						Required:    true,
+						MinItems:    1,
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+				},
+			},
+		},
+		"include": {
+			Type:        schema.TypeList,
+			Description: "Include is a list of named combinations which add parameters to the generated combinations, or add combinations of their own.",
+			Optional:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:        schema.TypeString,
+						Description: "Name the specified combination.",
+						Optional:    true,
+					},
+					"params": {
+						Type:        schema.TypeList,
+						Description: "Params takes only string parameters.",
+						Optional:    true,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"name": {
+									Type:        schema.TypeString,
+									Description: "Name of the parameter.",
+									Required:    true,
+								},
+								"value": {
+									Type:        schema.TypeString,
+									Description: "Value of the parameter.",
+									Required:    true,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func expandTektonMatrix(in []interface{}) *tektonapiv1.Matrix {
+	if len(in) == 0 || in[0] == nil {
+		return nil
+	}
+
+	m := in[0].(map[string]interface{})
+	result := &tektonapiv1.Matrix{}
+
+	for _, p := range m["params"].([]interface{}) {
+		param, ok := p.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		result.Params = append(result.Params, tektonapiv1.Param{
+			Name: param["name"].(string),
+			Value: tektonapiv1.ParamValue{
+				Type:     tektonapiv1.ParamTypeArray,
+				ArrayVal: utils.ExpandStringSlice(param["value"].([]interface{})),
+			},
+		})
+	}
+
+	for _, i := range m["include"].([]interface{}) {
+		include, ok := i.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		ip := tektonapiv1.IncludeParams{
+			Name: include["name"].(string),
+		}
+		for _, p := range include["params"].([]interface{}) {
+			param, ok := p.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			ip.Params = append(ip.Params, tektonapiv1.Param{
+				Name: param["name"].(string),
+				Value: tektonapiv1.ParamValue{
+					Type:      tektonapiv1.ParamTypeString,
+					StringVal: param["value"].(string),
+				},
+			})
+		}
+		result.Include = append(result.Include, ip)
+	}
+
+	return result
+}
+
+func flattenTektonMatrix(in *tektonapiv1.Matrix) []interface{} {
+	if in == nil {
+		return []interface{}{}
+	}
+
+	params := make([]interface{}, 0, len(in.Params))
+	for _, p := range in.Params {
+		params = append(params, map[string]interface{}{
+			"name":  p.Name,
+			"value": p.Value.ArrayVal,
+		})
+	}
+
+	include := make([]interface{}, 0, len(in.Include))
+	for _, i := range in.Include {
+		ips := make([]interface{}, 0, len(i.Params))
+		for _, p := range i.Params {
+			ips = append(ips, map[string]interface{}{
+				"name":  p.Name,
+				"value": p.Value.StringVal,
+			})
+		}
+		include = append(include, map[string]interface{}{
+			"name":   i.Name,
+			"params": ips,
+		})
+	}
+
+	att := make(map[string]interface{})
+	att["params"] = params
+	att["include"] = include
+
+	return []interface{}{att}
+}
+
+// HasMatrix returns whether a task or a finally task of the pipeline spec is matrixed, so the
+// combinations count only needs validating, and the Tekton defaults reading, in that case.
+func HasMatrix(spec []interface{}) bool {
+	if len(spec) == 0 || spec[0] == nil {
+		return false
+	}
+
+	in := spec[0].(map[string]interface{})
+	for _, key := range []string{"tasks", "finally"} {
+		tasks, _ := in[key].([]interface{})
+		for _, t := range tasks {
+			m, ok := t.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if matrix, _ := m["matrix"].([]interface{}); len(matrix) > 0 && matrix[0] != nil {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// ValidateMatrixCombinationsCount checks that no matrixed task in the pipeline spec fans out into
+// more TaskRuns than the Tekton installation allows, see default-max-matrix-combinations-count.
+func ValidateMatrixCombinationsCount(spec []interface{}, max int) error {
+	if len(spec) == 0 || spec[0] == nil {
+		return nil
+	}
+
+	in := spec[0].(map[string]interface{})
+	for _, key := range []string{"tasks", "finally"} {
+		kind := "pipeline task"
+		if key == "finally" {
+			kind = "finally task"
+		}
+		tasks, _ := in[key].([]interface{})
+		for _, t := range tasks {
+			m, ok := t.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			matrix, _ := m["matrix"].([]interface{})
+			count := expandTektonMatrix(matrix).CountCombinations()
+			if count > max {
+				return fmt.Errorf("%s %q: matrix generates %d combinations, which exceeds the maximum of %d set by default-max-matrix-combinations-count", kind, m["name"], count, max)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,156 @@
+package pipeline
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestValidateMatrixCombinationsCount(t *testing.T) {
+	params := func(values ...[]interface{}) []interface{} {
+		result := make([]interface{}, 0, len(values))
+		for i, v := range values {
+			result = append(result, map[string]interface{}{
+				"name":  fmt.Sprintf("param-%d", i),
+				"value": v,
+			})
+		}
+		return result
+	}
+	include := func(values ...string) []interface{} {
+		result := make([]interface{}, 0, len(values))
+		for _, value := range values {
+			result = append(result, map[string]interface{}{
+				"name": value,
+				"params": []interface{}{
+					map[string]interface{}{"name": "param-0", "value": value},
+				},
+			})
+		}
+		return result
+	}
+	spec := func(key string, params, include []interface{}) []interface{} {
+		return []interface{}{map[string]interface{}{
+			key: []interface{}{
+				map[string]interface{}{
+					"name": "build",
+					"matrix": []interface{}{map[string]interface{}{
+						"params":  params,
+						"include": include,
+					}},
+				},
+			},
+		}}
+	}
+
+	testCases := []struct {
+		Spec          []interface{}
+		Max           int
+		ExpectedError string
+	}{
+		{
+			Spec: nil,
+			Max:  1,
+		},
+		{
+			Spec: []interface{}{map[string]interface{}{
+				"tasks": []interface{}{map[string]interface{}{"name": "build", "matrix": []interface{}{}}},
+			}},
+			Max: 1,
+		},
+		{
+			Spec: spec("tasks", params([]interface{}{"linux", "darwin"}, []interface{}{"amd64", "arm64", "s390x"}), []interface{}{}),
+			Max:  6,
+		},
+		{
+			Spec:          spec("tasks", params([]interface{}{"linux", "darwin"}, []interface{}{"amd64", "arm64", "s390x"}), []interface{}{}),
+			Max:           5,
+			ExpectedError: `pipeline task "build": matrix generates 6 combinations, which exceeds the maximum of 5`,
+		},
+		{
+			Spec: spec("tasks", []interface{}{}, include("one", "two", "three")),
+			Max:  3,
+		},
+		{
+			Spec:          spec("tasks", []interface{}{}, include("one", "two", "three")),
+			Max:           2,
+			ExpectedError: `pipeline task "build": matrix generates 3 combinations, which exceeds the maximum of 2`,
+		},
+		{
+			Spec: spec("tasks", params([]interface{}{"linux", "darwin"}), include("windows", "freebsd")),
+			Max:  4,
+		},
+		{
+			Spec: spec("tasks", params([]interface{}{"linux", "darwin"}), include("linux", "windows")),
+			Max:  3,
+		},
+		{
+			Spec:          spec("finally", params([]interface{}{"linux", "darwin", "windows"}, []interface{}{"amd64", "arm64"}), include("freebsd")),
+			Max:           6,
+			ExpectedError: `finally task "build": matrix generates 7 combinations, which exceeds the maximum of 6`,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			err := ValidateMatrixCombinationsCount(tc.Spec, tc.Max)
+			if tc.ExpectedError == "" {
+				if err != nil {
+					t.Fatalf("Expected no error, given: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.ExpectedError) {
+				t.Fatalf("Expected an error containing %q, given: %v", tc.ExpectedError, err)
+			}
+		})
+	}
+}
+
+func TestHasMatrix(t *testing.T) {
+	matrix := []interface{}{map[string]interface{}{
+		"params": []interface{}{map[string]interface{}{"name": "os", "value": []interface{}{"linux", "darwin"}}},
+	}}
+
+	testCases := []struct {
+		Spec     []interface{}
+		Expected bool
+	}{
+		{
+			Spec: nil,
+		},
+		{
+			Spec: []interface{}{nil},
+		},
+		{
+			Spec: []interface{}{map[string]interface{}{
+				"tasks":   []interface{}{map[string]interface{}{"name": "build", "matrix": []interface{}{}}},
+				"finally": []interface{}{map[string]interface{}{"name": "notify"}},
+			}},
+		},
+		{
+			Spec: []interface{}{map[string]interface{}{
+				"tasks": []interface{}{
+					map[string]interface{}{"name": "fetch"},
+					map[string]interface{}{"name": "build", "matrix": matrix},
+				},
+			}},
+			Expected: true,
+		},
+		{
+			Spec: []interface{}{map[string]interface{}{
+				"tasks":   []interface{}{map[string]interface{}{"name": "build"}},
+				"finally": []interface{}{map[string]interface{}{"name": "notify", "matrix": matrix}},
+			}},
+			Expected: true,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			if given := HasMatrix(tc.Spec); given != tc.Expected {
+				t.Fatalf("Expected HasMatrix to be %t, given: %t", tc.Expected, given)
+			}
+		})
+	}
+}
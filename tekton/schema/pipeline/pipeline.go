@@ -9,8 +9,9 @@ import (
 
 func TektonPipelineFields() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
-		"metadata": k8s.NamespacedMetadataSchema("Pipeline", false),
-		"spec":     tektonPipelineSpecSchema(),
+		"metadata":    k8s.NamespacedMetadataSchema("Pipeline", false),
+		"api_version": k8s.APIVersionSchema("Pipeline"),
+		"spec":        tektonPipelineSpecSchema(),
 	}
 }
 
@@ -27,7 +28,7 @@ func ExpandTektonPipeline(tkpps []interface{}) (*tektonapiv1.Pipeline, error) {
 		result.ObjectMeta = k8s.ExpandMetadata(v)
 	}
 	if v, ok := in["spec"].([]interface{}); ok {
-		spec, err := expandTektonPipelineSpec(v)
+		spec, err := ExpandTektonPipelineSpec(v)
 		if err != nil {
 			return result, err
 		}
@@ -41,7 +42,7 @@ func FlattenTektonPipeline(in tektonapiv1.Pipeline) []interface{} {
 	att := make(map[string]interface{})
 
 	att["metadata"] = k8s.FlattenMetadata(in.ObjectMeta)
-	att["spec"] = flattenTektonPipelineSpec(in.Spec)
+	att["spec"] = FlattenTektonPipelineSpec(in.Spec)
 
 	return []interface{}{att}
 }
@@ -50,7 +51,7 @@ func FromResourceData(resourceData *schema.ResourceData) (*tektonapiv1.Pipeline,
 	result := &tektonapiv1.Pipeline{}
 
 	result.ObjectMeta = k8s.ExpandMetadata(resourceData.Get("metadata").([]interface{}))
-	spec, err := expandTektonPipelineSpec(resourceData.Get("spec").([]interface{}))
+	spec, err := ExpandTektonPipelineSpec(resourceData.Get("spec").([]interface{}))
 	if err != nil {
 		return result, err
 	}
@@ -63,13 +64,31 @@ func ToResourceData(vm tektonapiv1.Pipeline, resourceData *schema.ResourceData)
 	if err := resourceData.Set("metadata", k8s.FlattenMetadata(vm.ObjectMeta)); err != nil {
 		return err
 	}
-	if err := resourceData.Set("spec", flattenTektonPipelineSpec(vm.Spec)); err != nil {
+	if err := resourceData.Set("spec", FlattenTektonPipelineSpec(vm.Spec)); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func AppendPatchOps(keyPrefix, pathPrefix string, resourceData *schema.ResourceData, ops []patch.PatchOperation) patch.PatchOperations {
-	return k8s.AppendPatchOps(keyPrefix+"metadata.0.", pathPrefix+"/metadata/", resourceData, ops)
+func AppendPatchOps(keyPrefix, pathPrefix string, resourceData *schema.ResourceData, ops []patch.PatchOperation) (patch.PatchOperations, error) {
+	ops = k8s.AppendResourceVersionTestOp(keyPrefix+"metadata.0.", pathPrefix+"/metadata/", resourceData, ops)
+	ops = k8s.AppendPatchOps(keyPrefix+"metadata.0.", pathPrefix+"/metadata/", resourceData, ops)
+	if resourceData.HasChange(keyPrefix + "spec") {
+		oldV, newV := resourceData.GetChange(keyPrefix + "spec")
+		oldSpec, err := ExpandTektonPipelineSpec(oldV.([]interface{}))
+		if err != nil {
+			return ops, err
+		}
+		newSpec, err := ExpandTektonPipelineSpec(newV.([]interface{}))
+		if err != nil {
+			return ops, err
+		}
+		diffOps, err := patch.DiffObjects(pathPrefix+"/spec", oldSpec, newSpec)
+		if err != nil {
+			return ops, err
+		}
+		ops = append(ops, diffOps...)
+	}
+	return ops, nil
 }
@@ -0,0 +1,375 @@
+package pipeline
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/rh01/terraform-provider-tekton/tekton/schema/task"
+	"github.com/rh01/terraform-provider-tekton/tekton/utils"
+	tektonapiv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func tektonPipelineTaskFields() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:         schema.TypeString,
+			Description:  "Name is the name of this task within the context of a Pipeline. Name is used as a coordinate with the `from` and `run_after` fields to establish the execution order of tasks relative to one another.",
+			Required:     true,
+			ValidateFunc: utils.ValidateName,
+		},
+		"display_name": {
+			Type:        schema.TypeString,
+			Description: "DisplayName is the display name of this task within the context of a Pipeline.",
+			Optional:    true,
+		},
+		"description": {
+			Type:        schema.TypeString,
+			Description: "Description is the description of this task within the context of a Pipeline.",
+			Optional:    true,
+		},
+		"task_ref": {
+			Type:        schema.TypeList,
+			Description: "TaskRef is a reference to a task definition. Exactly one of task_ref or task_spec must be set.",
+			Optional:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: TektonTaskRefFields(),
+			},
+		},
+		"task_spec": {
+			Type:        schema.TypeList,
+			Description: "TaskSpec is a specification of a task embedded in the pipeline. Exactly one of task_ref or task_spec must be set.",
+			Optional:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: task.TektonTaskSpecFields(),
+			},
+		},
+		"when": {
+			Type:        schema.TypeList,
+			Description: "When is a list of when expressions that need to be true for the task to run",
+			Optional:    true,
+			Elem: &schema.Resource{
+				Schema: tektonWhenExpressionFields(),
+			},
+		},
+		"params": {
+			Type:        schema.TypeList,
+			Description: "Parameters declares parameters passed to this task.",
+			Optional:    true,
+			Elem: &schema.Resource{
+				Schema: TektonParamFields(),
+			},
+		},
+		"matrix": {
+			Type:        schema.TypeList,
+			Description: "Matrix declares parameters used to fan out this task.",
+			Optional:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: tektonMatrixFields(),
+			},
+		},
+		"workspaces": {
+			Type:        schema.TypeList,
+			Description: "Workspaces maps workspaces from the pipeline spec to the workspaces declared in the Task.",
+			Optional:    true,
+			Elem: &schema.Resource{
+				Schema: tektonWorkspacePipelineTaskBindingFields(),
+			},
+		},
+		"run_after": {
+			Type:        schema.TypeList,
+			Description: "RunAfter is the list of PipelineTask names that should be executed before this Task executes. (Used to force a specific ordering in graph execution.)",
+			Optional:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"retries": {
+			Type:         schema.TypeInt,
+			Description:  "Retries represents how many times this task should be retried in case of task failure.",
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(0),
+		},
+		"timeout": {
+			Type:             schema.TypeString,
+			Description:      "Time after which the TaskRun times out. Defaults to 1 hour. Refer Go's ParseDuration documentation for expected format: https://golang.org/pkg/time/#ParseDuration",
+			Optional:         true,
+			ValidateFunc:     utils.ValidateDuration,
+			DiffSuppressFunc: utils.SuppressEquivalentDuration,
+		},
+	}
+}
+
+func TektonTaskRefFields() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:        schema.TypeString,
+			Description: "Name of the referent.",
+			Optional:    true,
+		},
+		"kind": {
+			Type:        schema.TypeString,
+			Description: "TaskKind indicates the Kind of the Task: namespaced Task or a custom task kind. Defaults to Task.",
+			Optional:    true,
+			Computed:    true,
+		},
+		"api_version": {
+			Type:        schema.TypeString,
+			Description: "API version of the referent. Only set for custom tasks.",
+			Optional:    true,
+		},
+		"resolver": {
+			Type:        schema.TypeString,
+			Description: "Resolver is the name of the resolver that should perform resolution of the referenced Tekton resource, such as \"git\" or \"bundles\".",
+			Optional:    true,
+		},
+		"params": {
+			Type:        schema.TypeList,
+			Description: "Params contains the parameters used to identify the referenced Tekton resource.",
+			Optional:    true,
+			Elem: &schema.Resource{
+				Schema: TektonParamFields(),
+			},
+		},
+	}
+}
+
+func TektonParamFields() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:        schema.TypeString,
+			Description: "Name of the parameter.",
+			Required:    true,
+		},
+		"value": {
+			Type:        schema.TypeList,
+			Description: "Value of the parameter.",
+			Required:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: task.TektonParamValueFields(),
+			},
+		},
+	}
+}
+
+func tektonWorkspacePipelineTaskBindingFields() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:        schema.TypeString,
+			Description: "Name is the name of the workspace as declared by the task",
+			Required:    true,
+		},
+		"workspace": {
+			Type:        schema.TypeString,
+			Description: "Workspace is the name of the workspace declared by the pipeline",
+			Optional:    true,
+		},
+		"sub_path": {
+			Type:        schema.TypeString,
+			Description: "SubPath is optionally a directory on the volume which should be used for this binding",
+			Optional:    true,
+		},
+	}
+}
+
+func expandTektonPipelineTasks(in []interface{}) ([]tektonapiv1.PipelineTask, error) {
+	if len(in) == 0 {
+		return nil, nil
+	}
+
+	result := make([]tektonapiv1.PipelineTask, 0, len(in))
+	for _, t := range in {
+		m, ok := t.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		pt, err := expandTektonPipelineTask(m)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, pt)
+	}
+
+	return result, nil
+}
+
+func expandTektonPipelineTask(in map[string]interface{}) (tektonapiv1.PipelineTask, error) {
+	result := tektonapiv1.PipelineTask{
+		Name:        in["name"].(string),
+		DisplayName: in["display_name"].(string),
+		Description: in["description"].(string),
+		When:        expandTektonWhenExpressions(in["when"].([]interface{})),
+		Params:      ExpandTektonParams(in["params"].([]interface{})),
+		Matrix:      expandTektonMatrix(in["matrix"].([]interface{})),
+		Workspaces:  expandTektonWorkspacePipelineTaskBindings(in["workspaces"].([]interface{})),
+		RunAfter:    utils.ExpandStringSlice(in["run_after"].([]interface{})),
+		Retries:     in["retries"].(int),
+	}
+
+	taskRef := in["task_ref"].([]interface{})
+	taskSpec := in["task_spec"].([]interface{})
+	if len(taskRef) > 0 && len(taskSpec) > 0 {
+		return result, fmt.Errorf("pipeline task %q: only one of task_ref or task_spec can be set", result.Name)
+	}
+	if len(taskRef) == 0 && len(taskSpec) == 0 {
+		return result, fmt.Errorf("pipeline task %q: one of task_ref or task_spec must be set", result.Name)
+	}
+	result.TaskRef = ExpandTektonTaskRef(taskRef)
+	if len(taskSpec) > 0 {
+		spec, err := task.ExpandTektonTaskSpec(taskSpec)
+		if err != nil {
+			return result, err
+		}
+		result.TaskSpec = &tektonapiv1.EmbeddedTask{TaskSpec: spec}
+	}
+
+	if v := in["timeout"].(string); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return result, err
+		}
+		result.Timeout = &metav1.Duration{Duration: d}
+	}
+
+	return result, nil
+}
+
+func ExpandTektonTaskRef(in []interface{}) *tektonapiv1.TaskRef {
+	if len(in) == 0 || in[0] == nil {
+		return nil
+	}
+
+	m := in[0].(map[string]interface{})
+
+	return &tektonapiv1.TaskRef{
+		Name:       m["name"].(string),
+		Kind:       tektonapiv1.TaskKind(m["kind"].(string)),
+		APIVersion: m["api_version"].(string),
+		ResolverRef: tektonapiv1.ResolverRef{
+			Resolver: tektonapiv1.ResolverName(m["resolver"].(string)),
+			Params:   ExpandTektonParams(m["params"].([]interface{})),
+		},
+	}
+}
+
+func ExpandTektonParams(in []interface{}) tektonapiv1.Params {
+	if len(in) == 0 {
+		return nil
+	}
+
+	result := make(tektonapiv1.Params, 0, len(in))
+	for _, p := range in {
+		m, ok := p.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		param := tektonapiv1.Param{
+			Name: m["name"].(string),
+		}
+		if value := task.ExpandTektonParamValue(m["value"].([]interface{})); value != nil {
+			param.Value = *value
+		}
+		result = append(result, param)
+	}
+
+	return result
+}
+
+func expandTektonWorkspacePipelineTaskBindings(in []interface{}) []tektonapiv1.WorkspacePipelineTaskBinding {
+	if len(in) == 0 {
+		return nil
+	}
+
+	result := make([]tektonapiv1.WorkspacePipelineTaskBinding, 0, len(in))
+	for _, w := range in {
+		m, ok := w.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		result = append(result, tektonapiv1.WorkspacePipelineTaskBinding{
+			Name:      m["name"].(string),
+			Workspace: m["workspace"].(string),
+			SubPath:   m["sub_path"].(string),
+		})
+	}
+
+	return result
+}
+
+func flattenTektonPipelineTasks(in []tektonapiv1.PipelineTask) []interface{} {
+	result := make([]interface{}, 0, len(in))
+
+	for _, v := range in {
+		att := make(map[string]interface{})
+		att["name"] = v.Name
+		att["display_name"] = v.DisplayName
+		att["description"] = v.Description
+		att["task_ref"] = FlattenTektonTaskRef(v.TaskRef)
+		if v.TaskSpec != nil {
+			att["task_spec"] = task.FlattenTektonTaskSpec(v.TaskSpec.TaskSpec)
+		}
+		att["when"] = flattenTektonWhenExpressions(v.When)
+		att["params"] = FlattenTektonParams(v.Params)
+		att["matrix"] = flattenTektonMatrix(v.Matrix)
+		att["workspaces"] = flattenTektonWorkspacePipelineTaskBindings(v.Workspaces)
+		att["run_after"] = v.RunAfter
+		att["retries"] = v.Retries
+		if v.Timeout != nil {
+			att["timeout"] = v.Timeout.Duration.String()
+		}
+
+		result = append(result, att)
+	}
+
+	return result
+}
+
+func FlattenTektonTaskRef(in *tektonapiv1.TaskRef) []interface{} {
+	if in == nil {
+		return []interface{}{}
+	}
+
+	att := make(map[string]interface{})
+	att["name"] = in.Name
+	att["kind"] = string(in.Kind)
+	att["api_version"] = in.APIVersion
+	att["resolver"] = string(in.Resolver)
+	att["params"] = FlattenTektonParams(in.Params)
+
+	return []interface{}{att}
+}
+
+func FlattenTektonParams(in tektonapiv1.Params) []interface{} {
+	result := make([]interface{}, 0, len(in))
+
+	for _, v := range in {
+		att := make(map[string]interface{})
+		att["name"] = v.Name
+		att["value"] = task.FlattenTektonParamValue(&v.Value)
+
+		result = append(result, att)
+	}
+
+	return result
+}
+
+func flattenTektonWorkspacePipelineTaskBindings(in []tektonapiv1.WorkspacePipelineTaskBinding) []interface{} {
+	result := make([]interface{}, 0, len(in))
+
+	for _, v := range in {
+		att := make(map[string]interface{})
+		att["name"] = v.Name
+		att["workspace"] = v.Workspace
+		att["sub_path"] = v.SubPath
+
+		result = append(result, att)
+	}
+
+	return result
+}
@@ -15,9 +15,8 @@ func TektonPipelineSpecFields() map[string]*schema.Schema {
 			Type:        schema.TypeList,
 			Description: "Params is a list of input parameters required to run the task. Params must be supplied as inputs in PipelineRuns unless they declare a default value.",
 			Optional:    true,
-			MaxItems:    1,
 			Elem: &schema.Resource{
-				Schema: tektonParamSpecFields(),
+				Schema: task.TektonParamSpecFields("Pipeline"),
 			},
 		},
 		"display_name": {
@@ -32,10 +31,18 @@ func TektonPipelineSpecFields() map[string]*schema.Schema {
 		},
 		"tasks": {
 			Type:        schema.TypeList,
-			Description: "Tasks are the tasks of the build; each task is run sequentially with the source mounted into /workspace.",
+			Description: "Tasks declares the graph of Tasks that execute when this Pipeline is run.",
 			Optional:    true,
 			Elem: &schema.Resource{
-				Schema: task.TektonTaskFields(),
+				Schema: tektonPipelineTaskFields(),
+			},
+		},
+		"finally": {
+			Type:        schema.TypeList,
+			Description: "Finally declares the list of Tasks that execute just before leaving the Pipeline i.e. either after all Tasks are finished executing successfully or after a failure which would result in ending the Pipeline. They can read the execution status of the other tasks through $(tasks.<name>.status) and $(tasks.status).",
+			Optional:    true,
+			Elem: &schema.Resource{
+				Schema: tektonPipelineTaskFields(),
 			},
 		},
 		"workspaces": {
@@ -65,10 +72,10 @@ func tektonPipelineResultFields() map[string]*schema.Schema {
 			Required:    true,
 		},
 		"type": {
-			Type: schema.TypeString,
-
+			Type:         schema.TypeString,
 			Description:  "Type is the user-specified type of the result. The possible types are currently string, array and object, and string is the default.",
 			Optional:     true,
+			Computed:     true,
 			ValidateFunc: validation.StringInSlice([]string{"string", "array", "object"}, false),
 		},
 		"description": {
@@ -82,7 +89,7 @@ func tektonPipelineResultFields() map[string]*schema.Schema {
 			Optional:    true,
 			MaxItems:    1,
 			Elem: &schema.Resource{
-				Schema: tektonParamValueFields(),
+				Schema: task.TektonParamValueFields(),
 			},
 		},
 	}
@@ -103,95 +110,14 @@ func tektonPipelineSpecSchema() *schema.Schema {
 
 }
 
-func tektonParamSpecFields() map[string]*schema.Schema {
-	return map[string]*schema.Schema{
-		"name": {
-			Type:        schema.TypeString,
-			Description: "Name declares the name by which a parameter is referenced.",
-			Required:    true,
-		},
-		"type": {
-			Type:         schema.TypeString,
-			Description:  "Type is the user-specified type of the parameter. The possible types are currently string, array and object, and string is the default.",
-			Optional:     true,
-			ValidateFunc: validation.StringInSlice([]string{"string", "array", "object"}, false),
-		},
-		"description": {
-			Type:        schema.TypeString,
-			Description: "Description is a user-facing description of the parameter that may be used to populate a UI.",
-			Optional:    true,
-		},
-		"properties": {
-			Type:        schema.TypeMap,
-			Description: "Properties is the JSON Schema properties to support key-value pairs parameter.",
-			Optional:    true,
-			Elem: &schema.Resource{
-				Schema: tektonPropertySpecFields(),
-			},
-		},
-		"default": {
-			Type:        schema.TypeList,
-			Description: "Default is the value a parameter takes if no input value is supplied. If default is set, a Pipeline may be executed without a supplied value for the parameter.",
-			Optional:    true,
-			MaxItems:    1,
-			Elem: &schema.Resource{
-				Schema: tektonParamValueFields(),
-			},
-		},
-	}
-}
-
-func tektonPropertySpecFields() map[string]*schema.Schema {
-	return map[string]*schema.Schema{
-		"type": {
-			Type:         schema.TypeString,
-			Description:  "Type is the user-specified type of the parameter. The possible types are currently string, array and object, and string is the default.",
-			Optional:     true,
-			ValidateFunc: validation.StringInSlice([]string{"string", "array", "object"}, false),
-		},
-	}
-}
-
-func tektonParamValueFields() map[string]*schema.Schema {
-	return map[string]*schema.Schema{
-		"type": {
-			Type:         schema.TypeString,
-			Description:  "Type is the user-specified type of the parameter. The possible types are currently string, array and object, and string is the default.",
-			Optional:     true,
-			ValidateFunc: validation.StringInSlice([]string{"string", "array", "object"}, false),
-		},
-		"string_val": {
-			Type:        schema.TypeString,
-			Description: "StringVal is a string value.",
-			Optional:    true,
-		},
-		"array_val": {
-			Type:        schema.TypeList,
-			Description: "ArrayVal is an array of strings.",
-			Optional:    true,
-			Elem: &schema.Schema{
-				Type: schema.TypeString,
-			},
-		},
-		"object_val": {
-			Type:        schema.TypeMap,
-			Description: "ObjectVal is a map of strings to strings.",
-			Optional:    true,
-			Elem: &schema.Schema{
-				Type: schema.TypeString,
-			},
-		},
-	}
-}
-
-func expandTektonPipelineSpec(task []interface{}) (tektonapiv1.PipelineSpec, error) {
+func ExpandTektonPipelineSpec(pipeline []interface{}) (tektonapiv1.PipelineSpec, error) {
 	ppSpec := tektonapiv1.PipelineSpec{}
 
-	if len(task) == 0 || task[0] == nil {
+	if len(pipeline) == 0 || pipeline[0] == nil {
 		return ppSpec, nil
 	}
 
-	tktask := task[0].(map[string]interface{})
+	tktask := pipeline[0].(map[string]interface{})
 
 	// display_name is a user-facing name of the pipeline
 	if v, ok := tktask["display_name"]; ok {
@@ -205,16 +131,29 @@ func expandTektonPipelineSpec(task []interface{}) (tektonapiv1.PipelineSpec, err
 
 	// params
 	if v, ok := tktask["params"]; ok {
-		params := v.([]interface{})
-		for _, param := range params {
-			p := param.(map[string]interface{})
-			ppSpec.Params = append(ppSpec.Params, tektonapiv1.ParamSpec{
-				Name:        p["name"].(string),
-				Type:        tektonapiv1.ParamType(p["type"].(string)),
-				Description: p["description"].(string),
-				Default:     expandTektonParamValue(p["default"].([]interface{})),
-			})
+		ppSpec.Params = task.ExpandTektonParamSpecs(v.([]interface{}))
+	}
+
+	// tasks
+	if v, ok := tktask["tasks"]; ok {
+		tasks, err := expandTektonPipelineTasks(v.([]interface{}))
+		if err != nil {
+			return ppSpec, err
+		}
+		ppSpec.Tasks = tasks
+	}
+
+	// finally
+	if v, ok := tktask["finally"]; ok {
+		finally, err := expandTektonPipelineTasks(v.([]interface{}))
+		if err != nil {
+			return ppSpec, err
 		}
+		ppSpec.Finally = finally
+	}
+
+	if err := validateTaskStatusReferences(ppSpec.Tasks, ppSpec.Finally); err != nil {
+		return ppSpec, err
 	}
 
 	//results
@@ -222,12 +161,15 @@ func expandTektonPipelineSpec(task []interface{}) (tektonapiv1.PipelineSpec, err
 		results := v.([]interface{})
 		for _, res := range results {
 			r := res.(map[string]interface{})
-			ppSpec.Results = append(ppSpec.Results, tektonapiv1.PipelineResult{
+			result := tektonapiv1.PipelineResult{
 				Name:        r["name"].(string),
 				Type:        tektonapiv1.ResultsType(r["type"].(string)),
 				Description: r["description"].(string),
-				Value:       tektonapiv1.ResultValue(*expandTektonParamValue(r["value"].([]interface{}))),
-			})
+			}
+			if value := task.ExpandTektonParamValue(r["value"].([]interface{})); value != nil {
+				result.Value = *value
+			}
+			ppSpec.Results = append(ppSpec.Results, result)
 		}
 	}
 
@@ -247,58 +189,19 @@ func expandTektonPipelineSpec(task []interface{}) (tektonapiv1.PipelineSpec, err
 	return ppSpec, nil
 }
 
-func expandTektonParamValue(value []interface{}) *tektonapiv1.ParamValue {
-	if len(value) == 0 || value[0] == nil {
-		return nil
-	}
-
-	v := value[0].(map[string]interface{})
-
-	return &tektonapiv1.ParamValue{
-		Type:      tektonapiv1.ParamType(v["type"].(string)),
-		StringVal: v["string_val"].(string),
-		ArrayVal:  expandTektonArrayValue(v["array_val"].([]interface{})),
-	}
-}
-
-func expandTektonArrayValue(value []interface{}) []string {
-	var result []string
-
-	for _, v := range value {
-		result = append(result, v.(string))
-	}
-
-	return result
-}
-
-func flattenTektonPipelineSpec(in tektonapiv1.PipelineSpec) []interface{} {
+func FlattenTektonPipelineSpec(in tektonapiv1.PipelineSpec) []interface{} {
 	att := make(map[string]interface{})
 	att["display_name"] = in.DisplayName
 	att["description"] = in.Description
-	att["params"] = flattenTektonParamSpec(in.Params)
+	att["params"] = task.FlattenTektonParamSpecs(in.Params)
+	att["tasks"] = flattenTektonPipelineTasks(in.Tasks)
+	att["finally"] = flattenTektonPipelineTasks(in.Finally)
 	att["results"] = flattenTektonPipelineResult(in.Results)
 	att["workspaces"] = flattenTektonPipelineWorkspaceDeclaration(in.Workspaces)
 
 	return []interface{}{att}
 }
 
-func flattenTektonParamSpec(in []tektonapiv1.ParamSpec) []interface{} {
-
-	var result []interface{}
-
-	for _, v := range in {
-		att := make(map[string]interface{})
-		att["name"] = v.Name
-		att["type"] = v.Type
-		att["description"] = v.Description
-		att["default"] = flattenTektonParamValue(v.Default)
-
-		result = append(result, att)
-	}
-
-	return result
-}
-
 func flattenTektonPipelineResult(in []tektonapiv1.PipelineResult) []interface{} {
 
 	var result []interface{}
@@ -306,9 +209,9 @@ func flattenTektonPipelineResult(in []tektonapiv1.PipelineResult) []interface{}
 	for _, v := range in {
 		att := make(map[string]interface{})
 		att["name"] = v.Name
-		att["type"] = v.Type
+		att["type"] = string(v.Type)
 		att["description"] = v.Description
-		att["value"] = flattenTektonParamValue(&v.Value)
+		att["value"] = task.FlattenTektonParamValue(&v.Value)
 
 		result = append(result, att)
 	}
@@ -331,21 +234,3 @@ func flattenTektonPipelineWorkspaceDeclaration(in []tektonapiv1.PipelineWorkspac
 
 	return result
 }
-
-func flattenTektonParamValue(in *tektonapiv1.ParamValue) []interface{} {
-
-	var result []interface{}
-
-	if in == nil {
-		return result
-	}
-
-	att := make(map[string]interface{})
-	att["type"] = in.Type
-	att["string_val"] = in.StringVal
-	att["array_val"] = in.ArrayVal
-
-	result = append(result, att)
-
-	return result
-}
@@ -0,0 +1,76 @@
+package pipeline
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/rh01/terraform-provider-tekton/tekton/utils"
+	tektonapiv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"k8s.io/apimachinery/pkg/selection"
+)
+
+// allowedWhenOperators mirrors the operators accepted by the Tekton webhook for when expressions.
+var allowedWhenOperators = []string{
+	string(selection.In),
+	string(selection.NotIn),
+}
+
+func tektonWhenExpressionFields() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"input": {
+			Type:        schema.TypeString,
+			Description: "Input is the string for guard checking which can be a static input or an output from a parent Task",
+			Required:    true,
+		},
+		"operator": {
+			Type:         schema.TypeString,
+			Description:  "Operator that represents an Input's relationship to the values, either in or notin",
+			Required:     true,
+			ValidateFunc: validation.StringInSlice(allowedWhenOperators, false),
+		},
+		"values": {
+			Type:        schema.TypeList,
+			Description: "Values is an array of strings, which is compared against the input, for guard checking",
+			Required:    true,
+			MinItems:    1,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+	}
+}
+
+func expandTektonWhenExpressions(in []interface{}) tektonapiv1.WhenExpressions {
+	if len(in) == 0 {
+		return nil
+	}
+
+	result := make(tektonapiv1.WhenExpressions, 0, len(in))
+	for _, w := range in {
+		m, ok := w.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		result = append(result, tektonapiv1.WhenExpression{
+			Input:    m["input"].(string),
+			Operator: selection.Operator(m["operator"].(string)),
+			Values:   utils.ExpandStringSlice(m["values"].([]interface{})),
+		})
+	}
+
+	return result
+}
+
+func flattenTektonWhenExpressions(in tektonapiv1.WhenExpressions) []interface{} {
+	result := make([]interface{}, 0, len(in))
+
+	for _, v := range in {
+		att := make(map[string]interface{})
+		att["input"] = v.Input
+		att["operator"] = string(v.Operator)
+		att["values"] = v.Values
+
+		result = append(result, att)
+	}
+
+	return result
+}
@@ -0,0 +1,46 @@
+package pipeline_run
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	tektonapiv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+)
+
+const (
+	// DestroyBehaviorCancel cancels the run and its running tasks without running finally tasks.
+	DestroyBehaviorCancel = "cancel"
+	// DestroyBehaviorCancelRunFinally cancels the running tasks and runs the finally tasks.
+	DestroyBehaviorCancelRunFinally = "cancel_run_finally"
+	// DestroyBehaviorStopRunFinally lets the running tasks complete, then runs the finally tasks.
+	DestroyBehaviorStopRunFinally = "stop_run_finally"
+	// DestroyBehaviorDelete deletes the run right away.
+	DestroyBehaviorDelete = "delete"
+)
+
+var destroySpecStatus = map[string]tektonapiv1.PipelineRunSpecStatus{
+	DestroyBehaviorCancel:           tektonapiv1.PipelineRunSpecStatusCancelled,
+	DestroyBehaviorCancelRunFinally: tektonapiv1.PipelineRunSpecStatusCancelledRunFinally,
+	DestroyBehaviorStopRunFinally:   tektonapiv1.PipelineRunSpecStatusStoppedRunFinally,
+}
+
+func tektonDestroyBehaviorSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Description: "DestroyBehavior sets how the PipelineRun is stopped on destroy: cancel, cancel_run_finally or stop_run_finally set spec.status accordingly and wait for the run to complete before deleting it, delete deletes it right away. Defaults to delete.",
+		Optional:    true,
+		Default:     DestroyBehaviorDelete,
+		ValidateFunc: validation.StringInSlice([]string{
+			DestroyBehaviorCancel,
+			DestroyBehaviorCancelRunFinally,
+			DestroyBehaviorStopRunFinally,
+			DestroyBehaviorDelete,
+		}, false),
+	}
+}
+
+// DestroySpecStatus returns the spec.status which stops the run as requested by destroy_behavior,
+// or false when the run is deleted right away.
+func DestroySpecStatus(behavior string) (tektonapiv1.PipelineRunSpecStatus, bool) {
+	status, ok := destroySpecStatus[behavior]
+	return status, ok
+}
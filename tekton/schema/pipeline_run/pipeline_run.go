@@ -3,19 +3,30 @@ package pipeline_run
 import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/rh01/terraform-provider-tekton/tekton/schema/k8s"
+	"github.com/rh01/terraform-provider-tekton/tekton/schema/task_run"
 	"github.com/rh01/terraform-provider-tekton/tekton/utils/patch"
 	tektonapiv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 )
 
 func TektonPipelineRunFields() map[string]*schema.Schema {
-	return map[string]*schema.Schema{
-		"metadata": k8s.NamespacedMetadataSchema("PipelineRun", false),
-		"spec":     tektonPipelineRunSpecSchema(),
-		"status":   tektonPipelineRunStatusSchema(),
-	}
-}
+	fields := map[string]*schema.Schema{
+		"metadata":    k8s.NamespacedMetadataSchema("PipelineRun", true),
+		"api_version": k8s.APIVersionSchema("PipelineRun"),
+		"spec":        tektonPipelineRunSpecSchema(),
+		"status":      tektonPipelineRunStatusSchema(),
 
+		"destroy_behavior": tektonDestroyBehaviorSchema(),
+	}
+	fields["triggers"] = task_run.TektonRunTriggersSchema()
+	for k, v := range task_run.TektonRunWaitFields() {
+		fields[k] = v
+	}
+	for k, v := range task_run.TektonRunResultFields() {
+		fields[k] = v
+	}
 
+	return fields
+}
 
 func ExpandTektonPipelineRun(tkpps []interface{}) (*tektonapiv1.PipelineRun, error) {
 	result := &tektonapiv1.PipelineRun{}
@@ -45,6 +56,7 @@ func FlattenTektonPipelineRun(in tektonapiv1.PipelineRun) []interface{} {
 
 	att["metadata"] = k8s.FlattenMetadata(in.ObjectMeta)
 	att["spec"] = flattenTektonPipelineRunSpec(in.Spec)
+	att["status"] = flattenTektonPipelineRunStatus(in.Status)
 
 	return []interface{}{att}
 }
@@ -70,9 +82,23 @@ func ToResourceData(vm tektonapiv1.PipelineRun, resourceData *schema.ResourceDat
 		return err
 	}
 
+	if err := task_run.SetTektonPipelineRunResults(resourceData, vm.Status.Results); err != nil {
+		return err
+	}
+	if err := resourceData.Set("status", flattenTektonPipelineRunStatus(vm.Status)); err != nil {
+		return err
+	}
+
 	return nil
 }
 
 func AppendPatchOps(keyPrefix, pathPrefix string, resourceData *schema.ResourceData, ops []patch.PatchOperation) patch.PatchOperations {
+	if resourceData.HasChange(keyPrefix + "spec.0.status") {
+		if v := resourceData.Get(keyPrefix + "spec.0.status").(string); v != "" {
+			ops = append(ops, &patch.AddOperation{Path: pathPrefix + "/spec/status", Value: v})
+		} else {
+			ops = append(ops, &patch.RemoveOperation{Path: pathPrefix + "/spec/status"})
+		}
+	}
 	return k8s.AppendPatchOps(keyPrefix+"metadata.0.", pathPrefix+"/metadata/", resourceData, ops)
 }
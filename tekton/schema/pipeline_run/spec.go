@@ -2,57 +2,22 @@ package pipeline_run
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/rh01/terraform-provider-tekton/tekton/schema/pipeline"
+	"github.com/rh01/terraform-provider-tekton/tekton/schema/task_run"
+	"github.com/rh01/terraform-provider-tekton/tekton/utils"
 	tektonapiv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-/*
-*
-
-// PipelineRunSpec defines the desired state of PipelineRun
-
-	type PipelineRunSpec struct {
-		// +optional
-		PipelineRef *PipelineRef `json:"pipelineRef,omitempty"`
-		// +optional
-		PipelineSpec *PipelineSpec `json:"pipelineSpec,omitempty"`
-		// Params is a list of parameter names and values.
-		// +listType=atomic
-		Params Params `json:"params,omitempty"`
-
-		// Used for cancelling a pipelinerun (and maybe more later on)
-		// +optional
-		Status PipelineRunSpecStatus `json:"status,omitempty"`
-		// Time after which the Pipeline times out.
-		// Currently three keys are accepted in the map
-		// pipeline, tasks and finally
-		// with Timeouts.pipeline >= Timeouts.tasks + Timeouts.finally
-		// +optional
-		Timeouts *TimeoutFields `json:"timeouts,omitempty"`
-
-		// TaskRunTemplate represent template of taskrun
-		// +optional
-		TaskRunTemplate PipelineTaskRunTemplate `json:"taskRunTemplate,omitempty"`
-
-		// Workspaces holds a set of workspace bindings that must match names
-		// with those declared in the pipeline.
-		// +optional
-		// +listType=atomic
-		Workspaces []WorkspaceBinding `json:"workspaces,omitempty"`
-		// TaskRunSpecs holds a set of runtime specs
-		// +optional
-		// +listType=atomic
-		TaskRunSpecs []PipelineTaskRunSpec `json:"taskRunSpecs,omitempty"`
-	}
-*/
 func tektonPipelineRunSpecFields() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
 		"pipeline_ref": {
 			Type:        schema.TypeList,
-			Description: "PipelineRef is a reference to a Pipeline that is used to create PipelineRuns.",
+			Description: "PipelineRef is a reference to the Pipeline to run. Exactly one of pipeline_ref or pipeline_spec must be set.",
 			Optional:    true,
 			MaxItems:    1,
 			Elem: &schema.Resource{
@@ -61,7 +26,7 @@ func tektonPipelineRunSpecFields() map[string]*schema.Schema {
 		},
 		"pipeline_spec": {
 			Type:        schema.TypeList,
-			Description: "PipelineSpec is a specification of the desired behavior of a Pipeline.",
+			Description: "PipelineSpec is a specification of the Pipeline to run, embedded in the PipelineRun. Exactly one of pipeline_ref or pipeline_spec must be set.",
 			Optional:    true,
 			MaxItems:    1,
 			Elem: &schema.Resource{
@@ -70,34 +35,59 @@ func tektonPipelineRunSpecFields() map[string]*schema.Schema {
 		},
 		"params": {
 			Type:        schema.TypeList,
-			Description: "Params is a list of input parameters required to run the task. Params must be supplied as inputs in PipelineRunRuns unless they declare a default value.",
+			Description: "Params is a list of parameter names and values passed to the Pipeline.",
 			Optional:    true,
-			// MaxItems:    1,
 			Elem: &schema.Resource{
-				Schema: tektonParamSpecFields(),
+				Schema: pipeline.TektonParamFields(),
 			},
 		},
 		"status": {
 			Type:        schema.TypeString,
-			Description: "Status is the status of the PipelineRunSpec.",
+			Description: "Status is used for cancelling a PipelineRun, or creating it in a pending state.",
 			Optional:    true,
-			Default:     "Cancelled",
 			ValidateFunc: validation.StringInSlice([]string{
-				"Cancelled",
-				"CancelledRunFinally",
-				"StoppedRunFinally",
-				"PipelineRunPending",
+				string(tektonapiv1.PipelineRunSpecStatusCancelled),
+				string(tektonapiv1.PipelineRunSpecStatusCancelledRunFinally),
+				string(tektonapiv1.PipelineRunSpecStatusStoppedRunFinally),
+				string(tektonapiv1.PipelineRunSpecStatusPending),
 			}, false),
 		},
+		"timeouts": {
+			Type:        schema.TypeList,
+			Description: "Timeouts are the times after which the Pipeline, its tasks and its finally tasks time out, with timeouts.pipeline >= timeouts.tasks + timeouts.finally.",
+			Optional:    true,
+			Computed:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: tektonTimeoutFields(),
+			},
+		},
 		"task_run_template": {
 			Type:        schema.TypeList,
-			Description: "StepTemplate can be used as the basis for all step containers within the PipelineRun, so that the steps inherit settings on the base container.",
+			Description: "TaskRunTemplate represents the template applied to all the TaskRuns created by the PipelineRun.",
 			Optional:    true,
+			Computed:    true,
 			MaxItems:    1,
 			Elem: &schema.Resource{
 				Schema: tektonPipelineTaskRunTemplateFields(),
 			},
 		},
+		"workspaces": {
+			Type:        schema.TypeList,
+			Description: "Workspaces holds a set of workspace bindings that must match names with those declared in the pipeline.",
+			Optional:    true,
+			Elem: &schema.Resource{
+				Schema: task_run.TektonWorkspaceBindingFields(),
+			},
+		},
+		"task_run_specs": {
+			Type:        schema.TypeList,
+			Description: "TaskRunSpecs holds a set of runtime specs overriding the task_run_template for individual pipeline tasks.",
+			Optional:    true,
+			Elem: &schema.Resource{
+				Schema: tektonPipelineTaskRunSpecFields(),
+			},
+		},
 	}
 }
 
@@ -106,23 +96,67 @@ func tektonPipelineRefFields() map[string]*schema.Schema {
 		"name": {
 			Type:        schema.TypeString,
 			Description: "Name is the name of the referenced pipeline.",
-			Required:    true,
+			Optional:    true,
 		},
 		"api_version": {
 			Type:        schema.TypeString,
 			Description: "API version of the referent",
-			Required:    true,
+			Optional:    true,
+		},
+		"resolver": {
+			Type:        schema.TypeString,
+			Description: "Resolver is the name of the resolver that should perform resolution of the referenced Tekton resource, such as \"git\" or \"bundles\".",
+			Optional:    true,
+		},
+		"params": {
+			Type:        schema.TypeList,
+			Description: "Params contains the parameters used to identify the referenced Tekton resource.",
+			Optional:    true,
+			Elem: &schema.Resource{
+				Schema: pipeline.TektonParamFields(),
+			},
+		},
+	}
+}
+
+func tektonTimeoutFields() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"pipeline": {
+			Type:             schema.TypeString,
+			Description:      "Pipeline sets the maximum allowed duration for execution of the entire pipeline. The sum of individual timeouts for tasks and finally must not exceed this value. Defaults to the default-timeout-minutes of the Tekton installation.",
+			Optional:         true,
+			Computed:         true,
+			ValidateFunc:     utils.ValidateDuration,
+			DiffSuppressFunc: utils.SuppressEquivalentDuration,
+		},
+		"tasks": {
+			Type:             schema.TypeString,
+			Description:      "Tasks sets the maximum allowed duration of this pipeline's tasks.",
+			Optional:         true,
+			ValidateFunc:     utils.ValidateDuration,
+			DiffSuppressFunc: utils.SuppressEquivalentDuration,
+		},
+		"finally": {
+			Type:             schema.TypeString,
+			Description:      "Finally sets the maximum allowed duration of this pipeline's finally tasks.",
+			Optional:         true,
+			ValidateFunc:     utils.ValidateDuration,
+			DiffSuppressFunc: utils.SuppressEquivalentDuration,
 		},
 	}
 }
 
 func tektonPipelineRunSpecSchema() *schema.Schema {
-	fields := tektonPipelineRunSpecFields()
+	// A PipelineRun is not re-run when its spec changes, a new one is created instead. Only
+	// status can be updated in place, to cancel a run or start a pending one.
+	fields := utils.ForceNewFields(tektonPipelineRunSpecFields())
+	fields["status"] = tektonPipelineRunSpecFields()["status"]
 
 	return &schema.Schema{
 		Type:        schema.TypeList,
 		Description: fmt.Sprintf("TektonPipelineRunSpec describes how the proper TektonPipelineRun should look like."),
 		Required:    true,
+		ForceNew:    true,
 		MaxItems:    1,
 		Elem: &schema.Resource{
 			Schema: fields,
@@ -131,95 +165,110 @@ func tektonPipelineRunSpecSchema() *schema.Schema {
 
 }
 
-func tektonParamSpecFields() map[string]*schema.Schema {
-	return map[string]*schema.Schema{
-		"name": {
-			Type:        schema.TypeString,
-			Description: "Name declares the name by which a parameter is referenced.",
-			Required:    true,
-		},
-		"type": {
-			Type:         schema.TypeString,
-			Description:  "Type is the user-specified type of the parameter. The possible types are currently string, array and object, and string is the default.",
-			Optional:     true,
-			ValidateFunc: validation.StringInSlice([]string{"string", "array", "object"}, false),
-		},
-		"description": {
-			Type:        schema.TypeString,
-			Description: "Description is a user-facing description of the parameter that may be used to populate a UI.",
-			Optional:    true,
-		},
-		"properties": {
-			Type:        schema.TypeMap,
-			Description: "Properties is the JSON Schema properties to support key-value pairs parameter.",
-			Optional:    true,
-			Elem: &schema.Resource{
-				Schema: tektonPropertySpecFields(),
-			},
-		},
-		"default": {
-			Type:        schema.TypeList,
-			Description: "Default is the value a parameter takes if no input value is supplied. If default is set, a PipelineRun may be executed without a supplied value for the parameter.",
-			Optional:    true,
-			MaxItems:    1,
-			Elem: &schema.Resource{
-				Schema: tektonParamValueFields(),
-			},
-		},
+func expandTektonPipelineRunSpec(pipelineRun []interface{}) (tektonapiv1.PipelineRunSpec, error) {
+	result := tektonapiv1.PipelineRunSpec{}
+
+	if len(pipelineRun) == 0 || pipelineRun[0] == nil {
+		return result, nil
 	}
-}
 
-func tektonPropertySpecFields() map[string]*schema.Schema {
-	return map[string]*schema.Schema{
-		"type": {
-			Type:         schema.TypeString,
-			Description:  "Type is the user-specified type of the parameter. The possible types are currently string, array and object, and string is the default.",
-			Optional:     true,
-			ValidateFunc: validation.StringInSlice([]string{"string", "array", "object"}, false),
-		},
+	in := pipelineRun[0].(map[string]interface{})
+
+	pipelineRef := in["pipeline_ref"].([]interface{})
+	pipelineSpec := in["pipeline_spec"].([]interface{})
+	if len(pipelineRef) > 0 && len(pipelineSpec) > 0 {
+		return result, fmt.Errorf("only one of pipeline_ref or pipeline_spec can be set")
+	}
+	if len(pipelineRef) == 0 && len(pipelineSpec) == 0 {
+		return result, fmt.Errorf("one of pipeline_ref or pipeline_spec must be set")
+	}
+	result.PipelineRef = expandTektonPipelineRef(pipelineRef)
+	if len(pipelineSpec) > 0 {
+		spec, err := pipeline.ExpandTektonPipelineSpec(pipelineSpec)
+		if err != nil {
+			return result, err
+		}
+		result.PipelineSpec = &spec
+	}
+
+	if v, ok := in["params"].([]interface{}); ok {
+		result.Params = pipeline.ExpandTektonParams(v)
+	}
+	if v, ok := in["status"].(string); ok {
+		result.Status = tektonapiv1.PipelineRunSpecStatus(v)
+	}
+	if v, ok := in["timeouts"].([]interface{}); ok {
+		timeouts, err := expandTektonTimeoutFields(v)
+		if err != nil {
+			return result, err
+		}
+		result.Timeouts = timeouts
+	}
+	if v, ok := in["task_run_template"].([]interface{}); ok {
+		template, err := expandTektonPipelineTaskRunTemplate(v)
+		if err != nil {
+			return result, err
+		}
+		result.TaskRunTemplate = template
+	}
+	if v, ok := in["workspaces"].([]interface{}); ok {
+		workspaces, err := task_run.ExpandTektonWorkspaceBindings(v)
+		if err != nil {
+			return result, err
+		}
+		result.Workspaces = workspaces
+	}
+	if v, ok := in["task_run_specs"].([]interface{}); ok {
+		specs, err := expandTektonPipelineTaskRunSpecs(v)
+		if err != nil {
+			return result, err
+		}
+		result.TaskRunSpecs = specs
 	}
+
+	return result, nil
 }
 
-func tektonParamValueFields() map[string]*schema.Schema {
-	return map[string]*schema.Schema{
-		"type": {
-			Type:         schema.TypeString,
-			Description:  "Type is the user-specified type of the parameter. The possible types are currently string, array and object, and string is the default.",
-			Optional:     true,
-			ValidateFunc: validation.StringInSlice([]string{"string", "array", "object"}, false),
-		},
-		"string_val": {
-			Type:        schema.TypeString,
-			Description: "StringVal is a string value.",
-			Optional:    true,
-		},
-		"array_val": {
-			Type:        schema.TypeList,
-			Description: "ArrayVal is an array of strings.",
-			Optional:    true,
-			Elem: &schema.Schema{
-				Type: schema.TypeString,
-			},
-		},
-		"object_val": {
-			Type:        schema.TypeMap,
-			Description: "ObjectVal is a map of strings to strings.",
-			Optional:    true,
-			Elem: &schema.Schema{
-				Type: schema.TypeString,
-			},
+func expandTektonPipelineRef(in []interface{}) *tektonapiv1.PipelineRef {
+	if len(in) == 0 || in[0] == nil {
+		return nil
+	}
+
+	m := in[0].(map[string]interface{})
+
+	return &tektonapiv1.PipelineRef{
+		Name:       m["name"].(string),
+		APIVersion: m["api_version"].(string),
+		ResolverRef: tektonapiv1.ResolverRef{
+			Resolver: tektonapiv1.ResolverName(m["resolver"].(string)),
+			Params:   pipeline.ExpandTektonParams(m["params"].([]interface{})),
 		},
 	}
 }
 
-func expandTektonPipelineRunSpec(task []interface{}) (tektonapiv1.PipelineRunSpec, error) {
-	result := tektonapiv1.PipelineRunSpec{}
-
-	if len(task) == 0 || task[0] == nil {
-		return result, nil
+func expandTektonTimeoutFields(in []interface{}) (*tektonapiv1.TimeoutFields, error) {
+	if len(in) == 0 || in[0] == nil {
+		return nil, nil
 	}
 
-	_ = task[0].(map[string]interface{})
+	m := in[0].(map[string]interface{})
+	result := &tektonapiv1.TimeoutFields{}
+
+	for key, field := range map[string]**metav1.Duration{
+		"pipeline": &result.Pipeline,
+		"tasks":    &result.Tasks,
+		"finally":  &result.Finally,
+	} {
+		v, ok := m[key].(string)
+		if !ok || v == "" {
+			continue
+		}
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return result, fmt.Errorf("timeouts.%s: %s", key, err)
+		}
+		*field = &metav1.Duration{Duration: d}
+	}
 
 	return result, nil
 }
@@ -227,5 +276,49 @@ func expandTektonPipelineRunSpec(task []interface{}) (tektonapiv1.PipelineRunSpe
 func flattenTektonPipelineRunSpec(in tektonapiv1.PipelineRunSpec) []interface{} {
 	att := make(map[string]interface{})
 
+	att["pipeline_ref"] = flattenTektonPipelineRef(in.PipelineRef)
+	if in.PipelineSpec != nil {
+		att["pipeline_spec"] = pipeline.FlattenTektonPipelineSpec(*in.PipelineSpec)
+	}
+	att["params"] = pipeline.FlattenTektonParams(in.Params)
+	att["status"] = string(in.Status)
+	att["timeouts"] = flattenTektonTimeoutFields(in.Timeouts)
+	att["task_run_template"] = flattenTektonPipelineTaskRunTemplate(in.TaskRunTemplate)
+	att["workspaces"] = task_run.FlattenTektonWorkspaceBindings(in.Workspaces)
+	att["task_run_specs"] = flattenTektonPipelineTaskRunSpecs(in.TaskRunSpecs)
+
+	return []interface{}{att}
+}
+
+func flattenTektonPipelineRef(in *tektonapiv1.PipelineRef) []interface{} {
+	if in == nil {
+		return []interface{}{}
+	}
+
+	att := make(map[string]interface{})
+	att["name"] = in.Name
+	att["api_version"] = in.APIVersion
+	att["resolver"] = string(in.Resolver)
+	att["params"] = pipeline.FlattenTektonParams(in.Params)
+
+	return []interface{}{att}
+}
+
+func flattenTektonTimeoutFields(in *tektonapiv1.TimeoutFields) []interface{} {
+	if in == nil {
+		return []interface{}{}
+	}
+
+	att := make(map[string]interface{})
+	if in.Pipeline != nil {
+		att["pipeline"] = in.Pipeline.Duration.String()
+	}
+	if in.Tasks != nil {
+		att["tasks"] = in.Tasks.Duration.String()
+	}
+	if in.Finally != nil {
+		att["finally"] = in.Finally.Duration.String()
+	}
+
 	return []interface{}{att}
 }
@@ -0,0 +1,162 @@
+package pipeline_run
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	tektonapiv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPipelineRunSpecRoundTrip(t *testing.T) {
+	testCases := []struct {
+		Spec map[string]interface{}
+	}{
+		{
+			Spec: map[string]interface{}{
+				"pipeline_ref": []interface{}{map[string]interface{}{"name": "build"}},
+			},
+		},
+		{
+			Spec: map[string]interface{}{
+				"pipeline_ref": []interface{}{map[string]interface{}{
+					"resolver": "git",
+					"params": []interface{}{
+						map[string]interface{}{"name": "url", "value": []interface{}{map[string]interface{}{"string_val": "https://github.com/tektoncd/catalog.git"}}},
+						map[string]interface{}{"name": "pathInRepo", "value": []interface{}{map[string]interface{}{"string_val": "pipeline/build/0.1/build.yaml"}}},
+					},
+				}},
+				"params":     []interface{}{map[string]interface{}{"name": "flags", "value": []interface{}{map[string]interface{}{"array_val": []interface{}{"-v", "-race"}}}}},
+				"status":     "PipelineRunPending",
+				"timeouts":   []interface{}{map[string]interface{}{"pipeline": "2h0m0s", "tasks": "1h30m0s", "finally": "30m0s"}},
+				"workspaces": []interface{}{map[string]interface{}{"name": "source", "empty_dir": []interface{}{map[string]interface{}{}}}},
+				"task_run_template": []interface{}{map[string]interface{}{
+					"service_account_name": "builder",
+					"pod_template": []interface{}{map[string]interface{}{
+						"node_selector":                   map[string]interface{}{"kubernetes.io/arch": "amd64"},
+						"automount_service_account_token": false,
+					}},
+				}},
+				"task_run_specs": []interface{}{map[string]interface{}{
+					"pipeline_task_name":   "build",
+					"service_account_name": "builder",
+					"compute_resources":    []interface{}{map[string]interface{}{"limits": map[string]interface{}{"cpu": "2"}}},
+					"metadata":             []interface{}{map[string]interface{}{"labels": map[string]interface{}{"app": "build"}}},
+				}},
+			},
+		},
+		{
+			Spec: map[string]interface{}{
+				"pipeline_spec": []interface{}{map[string]interface{}{
+					"tasks": []interface{}{map[string]interface{}{
+						"name":     "build",
+						"task_ref": []interface{}{map[string]interface{}{"name": "build"}},
+					}},
+				}},
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			raw := map[string]interface{}{
+				"metadata": []interface{}{map[string]interface{}{"name": "build-1", "namespace": "default"}},
+				"spec":     []interface{}{tc.Spec},
+			}
+			expanded, err := FromResourceData(schema.TestResourceDataRaw(t, TektonPipelineRunFields(), raw))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resourceData := schema.TestResourceDataRaw(t, TektonPipelineRunFields(), map[string]interface{}{})
+			if err := ToResourceData(*expanded, resourceData); err != nil {
+				t.Fatal(err)
+			}
+			given, err := FromResourceData(resourceData)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !equality.Semantic.DeepEqual(expanded.Spec, given.Spec) {
+				expected, _ := json.Marshal(expanded.Spec)
+				actual, _ := json.Marshal(given.Spec)
+				t.Fatalf("PipelineRun specs don't match.\nExpected: %s\nGiven:    %s\n", expected, actual)
+			}
+		})
+	}
+}
+
+func TestPipelineRunSpecDefaultsDiff(t *testing.T) {
+	testCases := []struct {
+		Spec map[string]interface{}
+	}{
+		{
+			Spec: map[string]interface{}{
+				"pipeline_ref": []interface{}{map[string]interface{}{"name": "build"}},
+			},
+		},
+		{
+			Spec: map[string]interface{}{
+				"pipeline_ref": []interface{}{map[string]interface{}{"name": "build"}},
+				"timeouts":     []interface{}{map[string]interface{}{"tasks": "45m"}},
+				"task_run_template": []interface{}{map[string]interface{}{
+					"pod_template": []interface{}{map[string]interface{}{"node_selector": map[string]interface{}{"kubernetes.io/arch": "amd64"}}},
+				}},
+			},
+		},
+	}
+
+	fields := TektonPipelineRunFields()
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			raw := map[string]interface{}{
+				"metadata": []interface{}{map[string]interface{}{"name": "build-1", "namespace": "default"}},
+				"spec":     []interface{}{tc.Spec},
+			}
+			pipelineRun, err := FromResourceData(schema.TestResourceDataRaw(t, fields, raw))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// The Tekton webhook sets the defaults of the installation when the PipelineRun is created.
+			if pipelineRun.Spec.TaskRunTemplate.ServiceAccountName == "" {
+				pipelineRun.Spec.TaskRunTemplate.ServiceAccountName = "default"
+			}
+			if pipelineRun.Spec.Timeouts == nil {
+				pipelineRun.Spec.Timeouts = &tektonapiv1.TimeoutFields{}
+			}
+			if pipelineRun.Spec.Timeouts.Pipeline == nil {
+				pipelineRun.Spec.Timeouts.Pipeline = &metav1.Duration{Duration: time.Hour}
+			}
+
+			resourceData := schema.TestResourceDataRaw(t, fields, map[string]interface{}{})
+			if err := ToResourceData(*pipelineRun, resourceData); err != nil {
+				t.Fatal(err)
+			}
+			resourceData.SetId("default/build-1")
+
+			diff, err := schema.InternalMap(fields).Diff(context.Background(), resourceData.State(), terraform.NewResourceConfigRaw(raw), nil, nil, true)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff == nil {
+				return
+			}
+			for k, v := range diff.Attributes {
+				if strings.HasPrefix(k, "spec.") {
+					t.Fatalf("Expected no change of the spec, given: %s: %#v", k, v)
+				}
+			}
+			if diff.RequiresNew() {
+				t.Fatalf("Expected the PipelineRun not to be replaced, given: %#v", diff.Attributes)
+			}
+		})
+	}
+}
@@ -2,8 +2,8 @@ package pipeline_run
 
 import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	"github.com/rh01/terraform-provider-tekton/tekton/schema/pipeline"
+	"github.com/rh01/terraform-provider-tekton/tekton/schema/task_run"
+	tektonapiv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 )
 
 func tektonPipelineRunStatusSchema() *schema.Schema {
@@ -11,9 +11,8 @@ func tektonPipelineRunStatusSchema() *schema.Schema {
 
 	return &schema.Schema{
 		Type:        schema.TypeList,
-		Description: "Status is the current status of the PipelineRun",
-		Optional:    true,
-		MaxItems:    1,
+		Description: "Status is the current status of the PipelineRun, refreshed on every read.",
+		Computed:    true,
 		Elem: &schema.Resource{
 			Schema: fields,
 		},
@@ -25,36 +24,30 @@ func tektonPipelineRunStatusFields() map[string]*schema.Schema {
 		"start_time": {
 			Type:        schema.TypeString,
 			Description: "StartTime is the time the PipelineRun is actually started.",
-			Optional:    true,
+			Computed:    true,
 		},
 		"completion_time": {
 			Type:        schema.TypeString,
 			Description: "CompletionTime is the time the PipelineRun completed.",
-			Optional:    true,
+			Computed:    true,
 		},
-		"results": {
+		"conditions": {
 			Type:        schema.TypeList,
-			Description: "Results are the list of results written out by the pipeline task's containers",
-			Optional:    true,
-			MaxItems:    1,
+			Description: "Conditions are the latest available observations of the PipelineRun's state.",
+			Computed:    true,
 			Elem: &schema.Resource{
-				Schema: tektonPipelineRunResultSchema(),
+				Schema: task_run.TektonRunConditionFields(),
 			},
 		},
-		"pipeline_spec": {
-			Type:        schema.TypeList,
-			Description: "PipelineSpec contains the exact spec used to instantiate the run",
-			Optional:    true,
-			MaxItems:    1,
-			Elem: &schema.Resource{
-				Schema: pipeline.TektonPipelineSpecFields(),
-			},
+		"succeeded": {
+			Type:        schema.TypeBool,
+			Description: "Succeeded is true when the Succeeded condition of the PipelineRun is True.",
+			Computed:    true,
 		},
 		"skipped_tasks": {
 			Type:        schema.TypeList,
 			Description: "list of tasks that were skipped due to when expressions evaluating to false",
-			Optional:    true,
-			MaxItems:    1,
+			Computed:    true,
 			Elem: &schema.Resource{
 				Schema: tektonSkippedTaskSchema(),
 			},
@@ -62,8 +55,7 @@ func tektonPipelineRunStatusFields() map[string]*schema.Schema {
 		"child_references": {
 			Type:        schema.TypeList,
 			Description: "list of TaskRun and Run names, PipelineTask names, and API versions/kinds for children of this PipelineRun.",
-			Optional:    true,
-			MaxItems:    1,
+			Computed:    true,
 			Elem: &schema.Resource{
 				Schema: tektonChildStatusReferenceSchema(),
 			},
@@ -71,13 +63,16 @@ func tektonPipelineRunStatusFields() map[string]*schema.Schema {
 		"finally_start_time": {
 			Type:        schema.TypeString,
 			Description: "FinallyStartTime is when all non-finally tasks have been completed and only finally tasks are being executed.",
-			Optional:    true,
+			Computed:    true,
 		},
 
 		"span_context": {
 			Type:        schema.TypeMap,
 			Description: "SpanContext contains tracing span context fields",
-			Optional:    true,
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
 		},
 	}
 }
@@ -87,35 +82,19 @@ func tektonSkippedTaskSchema() map[string]*schema.Schema {
 		"name": {
 			Type:        schema.TypeString,
 			Description: "Name is the name of the task that was skipped",
-			Optional:    true,
+			Computed:    true,
 		},
 		"reason": {
 			Type:        schema.TypeString,
 			Description: "Reason is the reason the task was skipped",
-			Optional:    true,
-		},
-		"when_expression": {
-			Type:        schema.TypeString,
-			Description: "WhenExpression is the when expression that evaluated to false",
-			Optional:    true,
-		},
-	}
-}
-
-func tektonPipelineRunResultSchema() map[string]*schema.Schema {
-	return map[string]*schema.Schema{
-		"name": {
-			Type:        schema.TypeString,
-			Description: "Name is the result's name as declared by the Pipeline",
-			Optional:    true,
+			Computed:    true,
 		},
-		"value": {
+		"when_expressions": {
 			Type:        schema.TypeList,
-			Description: "Value is the result returned from the execution of this PipelineRun",
-			Optional:    true,
-			MaxItems:    1,
+			Description: "WhenExpressions is the list of checks guarding the execution of the PipelineTask",
+			Computed:    true,
 			Elem: &schema.Resource{
-				Schema: tektonParamValueFields(),
+				Schema: tektonWhenExpressionSchema(),
 			},
 		},
 	}
@@ -140,20 +119,30 @@ func tektonPipelineRunResultSchema() map[string]*schema.Schema {
 */
 func tektonChildStatusReferenceSchema() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
+		"api_version": {
+			Type:        schema.TypeString,
+			Description: "APIVersion is the API version of the child",
+			Computed:    true,
+		},
+		"kind": {
+			Type:        schema.TypeString,
+			Description: "Kind is the kind of the child, such as TaskRun or CustomRun",
+			Computed:    true,
+		},
 		"name": {
 			Type:        schema.TypeString,
 			Description: "Name is the name of the child",
-			Optional:    true,
+			Computed:    true,
 		},
 		"pipeline_task_name": {
 			Type:        schema.TypeString,
 			Description: "PipelineTaskName is the name of the PipelineTask this is referencing",
-			Optional:    true,
+			Computed:    true,
 		},
 		"when_expressions": {
 			Type:        schema.TypeList,
 			Description: "WhenExpressions is the list of checks guarding the execution of the PipelineTask",
-			Optional:    true,
+			Computed:    true,
 			Elem: &schema.Resource{
 				Schema: tektonWhenExpressionSchema(),
 			},
@@ -166,31 +155,82 @@ func tektonWhenExpressionSchema() map[string]*schema.Schema {
 		"input": {
 			Type:        schema.TypeString,
 			Description: "Input is the string for guard checking which can be a static input or an output from a parent Task",
-			Optional:    true,
+			Computed:    true,
 		},
 		"operator": {
 			Type:        schema.TypeString,
 			Description: "Operator that represents an Input's relationship to the values",
-			Optional:    true,
-			ValidateFunc: validation.StringInSlice([]string{
-				"!",
-				"=",
-				"==",
-				"in",
-				"!=",
-				"notin",
-				"exists",
-				"gt",
-				"lt",
-			}, false),
+			Computed:    true,
 		},
 		"values": {
 			Type:        schema.TypeList,
 			Description: "Values is an array of strings, which is compared against the input, for guard checking",
-			Optional:    true,
+			Computed:    true,
 			Elem: &schema.Schema{
 				Type: schema.TypeString,
 			},
 		},
 	}
 }
+
+func flattenTektonPipelineRunStatus(in tektonapiv1.PipelineRunStatus) []interface{} {
+	att := make(map[string]interface{})
+
+	att["start_time"] = task_run.FlattenTektonRunTime(in.StartTime)
+	att["completion_time"] = task_run.FlattenTektonRunTime(in.CompletionTime)
+	att["conditions"] = task_run.FlattenTektonRunConditions(in.Conditions)
+	att["succeeded"] = task_run.FlattenTektonRunSucceeded(in.Status)
+	att["skipped_tasks"] = flattenTektonSkippedTasks(in.SkippedTasks)
+	att["child_references"] = flattenTektonChildStatusReferences(in.ChildReferences)
+	att["finally_start_time"] = task_run.FlattenTektonRunTime(in.FinallyStartTime)
+	att["span_context"] = in.SpanContext
+
+	return []interface{}{att}
+}
+
+func flattenTektonSkippedTasks(in []tektonapiv1.SkippedTask) []interface{} {
+	result := make([]interface{}, 0, len(in))
+
+	for _, v := range in {
+		att := make(map[string]interface{})
+		att["name"] = v.Name
+		att["reason"] = string(v.Reason)
+		att["when_expressions"] = flattenTektonWhenExpressions(v.WhenExpressions)
+
+		result = append(result, att)
+	}
+
+	return result
+}
+
+func flattenTektonChildStatusReferences(in []tektonapiv1.ChildStatusReference) []interface{} {
+	result := make([]interface{}, 0, len(in))
+
+	for _, v := range in {
+		att := make(map[string]interface{})
+		att["api_version"] = v.APIVersion
+		att["kind"] = v.Kind
+		att["name"] = v.Name
+		att["pipeline_task_name"] = v.PipelineTaskName
+		att["when_expressions"] = flattenTektonWhenExpressions(v.WhenExpressions)
+
+		result = append(result, att)
+	}
+
+	return result
+}
+
+func flattenTektonWhenExpressions(in []tektonapiv1.WhenExpression) []interface{} {
+	result := make([]interface{}, 0, len(in))
+
+	for _, v := range in {
+		att := make(map[string]interface{})
+		att["input"] = v.Input
+		att["operator"] = string(v.Operator)
+		att["values"] = v.Values
+
+		result = append(result, att)
+	}
+
+	return result
+}
@@ -0,0 +1,87 @@
+package pipeline_run
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	tektonapiv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/selection"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+func TestFlattenTektonPipelineRunStatus(t *testing.T) {
+	start := metav1.NewTime(time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC))
+	finally := metav1.NewTime(start.Add(5 * time.Minute))
+	end := metav1.NewTime(start.Add(6 * time.Minute))
+
+	status := tektonapiv1.PipelineRunStatus{
+		Status: duckv1.Status{Conditions: duckv1.Conditions{
+			{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue, Reason: "Completed", Message: "Tasks Completed: 2, Skipped: 1", LastTransitionTime: apis.VolatileTime{Inner: end}},
+		}},
+		PipelineRunStatusFields: tektonapiv1.PipelineRunStatusFields{
+			StartTime:        &start,
+			CompletionTime:   &end,
+			FinallyStartTime: &finally,
+			SkippedTasks: []tektonapiv1.SkippedTask{{
+				Name:   "deploy",
+				Reason: tektonapiv1.WhenExpressionsSkip,
+				WhenExpressions: tektonapiv1.WhenExpressions{
+					{Input: "main", Operator: selection.In, Values: []string{"release"}},
+				},
+			}},
+			ChildReferences: []tektonapiv1.ChildStatusReference{
+				{TypeMeta: runtime.TypeMeta{APIVersion: "tekton.dev/v1", Kind: "TaskRun"}, Name: "build-1-build", PipelineTaskName: "build"},
+				{
+					TypeMeta:         runtime.TypeMeta{APIVersion: "tekton.dev/v1", Kind: "TaskRun"},
+					Name:             "build-1-notify",
+					PipelineTaskName: "notify",
+					WhenExpressions: tektonapiv1.WhenExpressions{
+						{Input: "Succeeded", Operator: selection.NotIn, Values: []string{"Failed", "None"}},
+					},
+				},
+			},
+		},
+	}
+
+	expected := []interface{}{map[string]interface{}{
+		"start_time":      "2023-05-01T10:00:00Z",
+		"completion_time": "2023-05-01T10:06:00Z",
+		"conditions": []interface{}{
+			map[string]interface{}{"type": "Succeeded", "status": "True", "reason": "Completed", "message": "Tasks Completed: 2, Skipped: 1", "last_transition_time": "2023-05-01T10:06:00Z"},
+		},
+		"succeeded": true,
+		"skipped_tasks": []interface{}{
+			map[string]interface{}{"name": "deploy", "reason": "When Expressions evaluated to false", "when_expressions": []interface{}{
+				map[string]interface{}{"input": "main", "operator": "in", "values": []string{"release"}},
+			}},
+		},
+		"child_references": []interface{}{
+			map[string]interface{}{"api_version": "tekton.dev/v1", "kind": "TaskRun", "name": "build-1-build", "pipeline_task_name": "build", "when_expressions": []interface{}{}},
+			map[string]interface{}{"api_version": "tekton.dev/v1", "kind": "TaskRun", "name": "build-1-notify", "pipeline_task_name": "notify", "when_expressions": []interface{}{
+				map[string]interface{}{"input": "Succeeded", "operator": "notin", "values": []string{"Failed", "None"}},
+			}},
+		},
+		"finally_start_time": "2023-05-01T10:05:00Z",
+		"span_context":       map[string]string(nil),
+	}}
+
+	given := flattenTektonPipelineRunStatus(status)
+	if !reflect.DeepEqual(given, expected) {
+		t.Fatalf("Statuses don't match.\nExpected: %#v\nGiven:    %#v\n", expected, given)
+	}
+
+	// The flattened status matches the schema of the attribute.
+	resourceData := schema.TestResourceDataRaw(t, TektonPipelineRunFields(), map[string]interface{}{})
+	if err := resourceData.Set("status", given); err != nil {
+		t.Fatal(err)
+	}
+	if v := resourceData.Get("status.0.child_references.1.when_expressions.0.values.1").(string); v != "None" {
+		t.Fatalf("Expected the value None of the when expression of notify, given: %q", v)
+	}
+}
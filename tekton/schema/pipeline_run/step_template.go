@@ -3,23 +3,45 @@ package pipeline_run
 import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/rh01/terraform-provider-tekton/tekton/schema/k8s"
+	tektonapiv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 )
 
 func tektonPipelineTaskRunTemplateFields() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
-		"pod_template": {
-			Type:        schema.TypeList,
-			Description: "PodTemplate is used to specify run specifications for all Task in pipelinerun",
-			Optional:    true,
-			MaxItems:    1,
-			Elem: &schema.Resource{
-				Schema: k8s.PodTemplateFields("pipelinerun"),
-			},
-		},
+		"pod_template": k8s.PodTemplateSchema("TaskRuns of the PipelineRun"),
 		"service_account_name": {
 			Type:        schema.TypeString,
-			Description: "ServiceAccountName is the name of the ServiceAccount to use to run this PipelineRun's Pods",
+			Description: "ServiceAccountName is the name of the ServiceAccount to use to run this PipelineRun's Pods. Defaults to the default-service-account of the Tekton installation.",
 			Optional:    true,
+			Computed:    true,
 		},
 	}
 }
+
+func expandTektonPipelineTaskRunTemplate(in []interface{}) (tektonapiv1.PipelineTaskRunTemplate, error) {
+	result := tektonapiv1.PipelineTaskRunTemplate{}
+
+	if len(in) == 0 || in[0] == nil {
+		return result, nil
+	}
+
+	m := in[0].(map[string]interface{})
+
+	podTemplate, err := k8s.ExpandPodTemplate(m["pod_template"].([]interface{}))
+	if err != nil {
+		return result, err
+	}
+	result.PodTemplate = podTemplate
+	result.ServiceAccountName = m["service_account_name"].(string)
+
+	return result, nil
+}
+
+func flattenTektonPipelineTaskRunTemplate(in tektonapiv1.PipelineTaskRunTemplate) []interface{} {
+	att := make(map[string]interface{})
+
+	att["pod_template"] = k8s.FlattenPodTemplate(in.PodTemplate)
+	att["service_account_name"] = in.ServiceAccountName
+
+	return []interface{}{att}
+}
@@ -0,0 +1,155 @@
+package pipeline_run
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/rh01/terraform-provider-tekton/tekton/schema/k8s"
+	"github.com/rh01/terraform-provider-tekton/tekton/schema/task_run"
+	"github.com/rh01/terraform-provider-tekton/tekton/utils"
+	tektonapiv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+)
+
+func tektonPipelineTaskRunSpecFields() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"pipeline_task_name": {
+			Type:        schema.TypeString,
+			Description: "PipelineTaskName is the name of the pipeline task the spec applies to.",
+			Required:    true,
+		},
+		"service_account_name": {
+			Type:        schema.TypeString,
+			Description: "ServiceAccountName is the name of the ServiceAccount to use to run the TaskRun of the pipeline task.",
+			Optional:    true,
+		},
+		"pod_template": k8s.PodTemplateSchema("TaskRun of the pipeline task"),
+		"step_specs": {
+			Type:        schema.TypeList,
+			Description: "StepSpecs overrides the compute resources of the Steps of the Task.",
+			Optional:    true,
+			Elem: &schema.Resource{
+				Schema: task_run.TektonTaskRunStepSpecFields(),
+			},
+		},
+		"sidecar_specs": {
+			Type:        schema.TypeList,
+			Description: "SidecarSpecs overrides the compute resources of the Sidecars of the Task.",
+			Optional:    true,
+			Elem: &schema.Resource{
+				Schema: task_run.TektonTaskRunSidecarSpecFields(),
+			},
+		},
+		"metadata": {
+			Type:        schema.TypeList,
+			Description: "Metadata holds the labels and annotations added to the TaskRun of the pipeline task.",
+			Optional:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"labels": {
+						Type:        schema.TypeMap,
+						Description: "Map of string keys and values added to the labels of the TaskRun.",
+						Optional:    true,
+						Elem:        &schema.Schema{Type: schema.TypeString},
+					},
+					"annotations": {
+						Type:        schema.TypeMap,
+						Description: "Map of string keys and values added to the annotations of the TaskRun.",
+						Optional:    true,
+						Elem:        &schema.Schema{Type: schema.TypeString},
+					},
+				},
+			},
+		},
+		"compute_resources": k8s.ResourceRequirementsSchema("ComputeResources are the compute resources to use for the TaskRun of the pipeline task."),
+	}
+}
+
+func expandTektonPipelineTaskRunSpecs(in []interface{}) ([]tektonapiv1.PipelineTaskRunSpec, error) {
+	if len(in) == 0 {
+		return nil, nil
+	}
+
+	result := make([]tektonapiv1.PipelineTaskRunSpec, 0, len(in))
+	for _, s := range in {
+		m, ok := s.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		spec := tektonapiv1.PipelineTaskRunSpec{
+			PipelineTaskName:   m["pipeline_task_name"].(string),
+			ServiceAccountName: m["service_account_name"].(string),
+			Metadata:           expandTektonPipelineTaskMetadata(m["metadata"].([]interface{})),
+		}
+
+		podTemplate, err := k8s.ExpandPodTemplate(m["pod_template"].([]interface{}))
+		if err != nil {
+			return result, err
+		}
+		spec.PodTemplate = podTemplate
+
+		stepSpecs, err := task_run.ExpandTektonTaskRunStepSpecs(m["step_specs"].([]interface{}))
+		if err != nil {
+			return result, err
+		}
+		spec.StepSpecs = stepSpecs
+
+		sidecarSpecs, err := task_run.ExpandTektonTaskRunSidecarSpecs(m["sidecar_specs"].([]interface{}))
+		if err != nil {
+			return result, err
+		}
+		spec.SidecarSpecs = sidecarSpecs
+
+		computeResources, err := k8s.ExpandResourceRequirements(m["compute_resources"].([]interface{}))
+		if err != nil {
+			return result, err
+		}
+		spec.ComputeResources = computeResources
+
+		result = append(result, spec)
+	}
+
+	return result, nil
+}
+
+func expandTektonPipelineTaskMetadata(in []interface{}) *tektonapiv1.PipelineTaskMetadata {
+	if len(in) == 0 || in[0] == nil {
+		return nil
+	}
+
+	m := in[0].(map[string]interface{})
+
+	return &tektonapiv1.PipelineTaskMetadata{
+		Labels:      utils.ExpandStringMap(m["labels"].(map[string]interface{})),
+		Annotations: utils.ExpandStringMap(m["annotations"].(map[string]interface{})),
+	}
+}
+
+func flattenTektonPipelineTaskRunSpecs(in []tektonapiv1.PipelineTaskRunSpec) []interface{} {
+	result := make([]interface{}, 0, len(in))
+
+	for _, v := range in {
+		att := make(map[string]interface{})
+		att["pipeline_task_name"] = v.PipelineTaskName
+		att["service_account_name"] = v.ServiceAccountName
+		att["pod_template"] = k8s.FlattenPodTemplate(v.PodTemplate)
+		att["step_specs"] = task_run.FlattenTektonTaskRunStepSpecs(v.StepSpecs)
+		att["sidecar_specs"] = task_run.FlattenTektonTaskRunSidecarSpecs(v.SidecarSpecs)
+		att["metadata"] = flattenTektonPipelineTaskMetadata(v.Metadata)
+		att["compute_resources"] = k8s.FlattenResourceRequirements(v.ComputeResources)
+
+		result = append(result, att)
+	}
+
+	return result
+}
+
+func flattenTektonPipelineTaskMetadata(in *tektonapiv1.PipelineTaskMetadata) []interface{} {
+	if in == nil {
+		return []interface{}{}
+	}
+
+	att := make(map[string]interface{})
+	att["labels"] = utils.FlattenStringMap(in.Labels)
+	att["annotations"] = utils.FlattenStringMap(in.Annotations)
+
+	return []interface{}{att}
+}
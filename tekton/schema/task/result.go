@@ -0,0 +1,76 @@
+package task
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	tektonapiv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+)
+
+func tektonTaskResultFields() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:        schema.TypeString,
+			Description: "Name the given name",
+			Required:    true,
+		},
+		"type": {
+			Type:         schema.TypeString,
+			Description:  "Type is the user-specified type of the result. The possible types are string, array and object, and string is the default.",
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.StringInSlice([]string{"string", "array", "object"}, false),
+		},
+		"properties": {
+			Type:        schema.TypeMap,
+			Description: "Properties is the JSON Schema properties to support key-value pairs results, as a map of key name to its type.",
+			Optional:    true,
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringInSlice([]string{"string", "array", "object"}, false),
+			},
+		},
+		"description": {
+			Type:        schema.TypeString,
+			Description: "Description is a human-readable description of the result",
+			Optional:    true,
+		},
+	}
+}
+
+func expandTektonTaskResults(in []interface{}) []tektonapiv1.TaskResult {
+	if len(in) == 0 {
+		return nil
+	}
+
+	result := make([]tektonapiv1.TaskResult, 0, len(in))
+	for _, r := range in {
+		m, ok := r.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		result = append(result, tektonapiv1.TaskResult{
+			Name:        m["name"].(string),
+			Type:        tektonapiv1.ResultsType(m["type"].(string)),
+			Properties:  expandTektonPropertySpecs(m["properties"].(map[string]interface{})),
+			Description: m["description"].(string),
+		})
+	}
+
+	return result
+}
+
+func flattenTektonTaskResults(in []tektonapiv1.TaskResult) []interface{} {
+	result := make([]interface{}, 0, len(in))
+
+	for _, v := range in {
+		att := make(map[string]interface{})
+		att["name"] = v.Name
+		att["type"] = string(v.Type)
+		att["properties"] = flattenTektonPropertySpecs(v.Properties)
+		att["description"] = v.Description
+
+		result = append(result, att)
+	}
+
+	return result
+}
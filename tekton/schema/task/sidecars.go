@@ -1,8 +1,15 @@
 package task
 
-import "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/rh01/terraform-provider-tekton/tekton/schema/k8s"
+	"github.com/rh01/terraform-provider-tekton/tekton/utils"
+	tektonapiv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+)
 
-func tektonSidecarFields() map[string]*schema.Schema{
+func tektonSidecarFields() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
 		"name": {
 			Type:        schema.TypeString,
@@ -35,43 +42,150 @@ func tektonSidecarFields() map[string]*schema.Schema{
 			Description: "Working directory to use when executing the sidecar",
 			Optional:    true,
 		},
-		"env": {
+		"env":      k8s.EnvSchema("Environment variables to set for the sidecar"),
+		"env_from": k8s.EnvFromSchema("ConfigMaps and Secrets whose keys are set as environment variables of the sidecar"),
+		"volume_mounts": {
 			Type:        schema.TypeList,
-			Description: "Environment variables to set for the sidecar",
+			Description: "Volume mounts for the sidecar",
 			Optional:    true,
 			Elem: &schema.Resource{
-				Schema: map[string]*schema.Schema{
-					"name": {
-						Type:        schema.TypeString,
-						Description: "Name of the environment variable",
-						Required:    true,
-					},
-					"value": {
-						Type:        schema.TypeString,
-						Description: "Value of the environment variable",
-						Required:    true,
-					},
-				},
+				Schema: volumeMountFields(),
 			},
 		},
-		"volume_mounts": {
+		"volume_devices": k8s.VolumeDevicesSchema("Block devices to map into the sidecar"),
+		"image_pull_policy": {
+			Type:        schema.TypeString,
+			Description: "Image pull policy for the sidecar",
+			Optional:    true,
+		},
+		"ports":             k8s.PortsSchema("Ports to expose from the sidecar"),
+		"liveness_probe":    k8s.ProbeSchema("Periodic probe of the sidecar liveness, the sidecar is restarted if the probe fails"),
+		"readiness_probe":   k8s.ProbeSchema("Periodic probe of the sidecar readiness, the steps start once the sidecar is ready"),
+		"startup_probe":     k8s.ProbeSchema("Probe of the sidecar startup, the other probes are executed once it succeeds"),
+		"lifecycle":         k8s.LifecycleSchema("Actions to take in response to the lifecycle events of the sidecar"),
+		"compute_resources": k8s.ResourceRequirementsSchema("Compute resources required by the sidecar"),
+		"security_context":  k8s.SecurityContextSchema("Security options the sidecar should run with"),
+		"termination_message_path": {
+			Type:        schema.TypeString,
+			Description: "Path of the file the termination message of the sidecar is written to, defaults to /dev/termination-log",
+			Optional:    true,
+		},
+		"termination_message_policy": {
+			Type:         schema.TypeString,
+			Description:  "How the termination message of the sidecar is populated, either File or FallbackToLogsOnError",
+			Optional:     true,
+			ValidateFunc: validation.StringInSlice([]string{string(corev1.TerminationMessageReadFile), string(corev1.TerminationMessageFallbackToLogsOnError)}, false),
+		},
+		"stdin": {
+			Type:        schema.TypeBool,
+			Description: "Whether the sidecar should allocate a buffer for stdin, reads from stdin result in EOF otherwise",
+			Optional:    true,
+		},
+		"tty": {
+			Type:        schema.TypeBool,
+			Description: "Whether the sidecar should allocate a TTY for itself, stdin must be true too",
+			Optional:    true,
+		},
+		"script": {
+			Type:        schema.TypeString,
+			Description: "Contents of an executable file to execute in the sidecar",
+			Optional:    true,
+		},
+		"workspaces": {
 			Type:        schema.TypeList,
-			Description: "Volume mounts for the sidecar",
+			Description: "Workspaces from the Task that this Sidecar wants access to",
 			Optional:    true,
 			Elem: &schema.Resource{
-				Schema: map[string]*schema.Schema{
-					"name": {
-						Type:        schema.TypeString,
-						Description: "Name of the volume mount",
-						Required:    true,
-					},
-					"mount_path": {
-						Type:        schema.TypeString,
-						Description: "Path to mount the volume",
-						Required:    true,
-					},
-				},
+				Schema: workspaceUsageFields(),
 			},
 		},
 	}
-}
\ No newline at end of file
+}
+
+func expandTektonSidecars(in []interface{}) ([]tektonapiv1.Sidecar, error) {
+	if len(in) == 0 {
+		return nil, nil
+	}
+
+	result := make([]tektonapiv1.Sidecar, 0, len(in))
+	for _, s := range in {
+		m, ok := s.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		sidecar := tektonapiv1.Sidecar{
+			Name:            m["name"].(string),
+			Image:           m["image"].(string),
+			Command:         utils.ExpandStringSlice(m["command"].([]interface{})),
+			Args:            utils.ExpandStringSlice(m["args"].([]interface{})),
+			WorkingDir:      m["working_dir"].(string),
+			VolumeMounts:    expandTektonVolumeMounts(m["volume_mounts"].([]interface{})),
+			VolumeDevices:   k8s.ExpandVolumeDevices(m["volume_devices"].([]interface{})),
+			ImagePullPolicy: corev1.PullPolicy(m["image_pull_policy"].(string)),
+			LivenessProbe:   k8s.ExpandProbe(m["liveness_probe"].([]interface{})),
+			ReadinessProbe:  k8s.ExpandProbe(m["readiness_probe"].([]interface{})),
+			StartupProbe:    k8s.ExpandProbe(m["startup_probe"].([]interface{})),
+			Lifecycle:       k8s.ExpandLifecycle(m["lifecycle"].([]interface{})),
+			Script:          m["script"].(string),
+			Workspaces:      expandTektonWorkspaceUsages(m["workspaces"].([]interface{})),
+		}
+		if v, ok := m["termination_message_path"].(string); ok {
+			sidecar.TerminationMessagePath = v
+		}
+		if v, ok := m["termination_message_policy"].(string); ok {
+			sidecar.TerminationMessagePolicy = corev1.TerminationMessagePolicy(v)
+		}
+		if v, ok := m["stdin"].(bool); ok {
+			sidecar.Stdin = v
+		}
+		if v, ok := m["tty"].(bool); ok {
+			sidecar.TTY = v
+		}
+		ports, err := k8s.ExpandPorts(m["ports"].([]interface{}))
+		if err != nil {
+			return result, err
+		}
+		sidecar.Ports = ports
+		if err := expandTektonContainerFields(m, &sidecar.Env, &sidecar.EnvFrom, &sidecar.ComputeResources, &sidecar.SecurityContext); err != nil {
+			return result, err
+		}
+		result = append(result, sidecar)
+	}
+
+	return result, nil
+}
+
+func flattenTektonSidecars(in []tektonapiv1.Sidecar) []interface{} {
+	result := make([]interface{}, 0, len(in))
+
+	for _, v := range in {
+		att := make(map[string]interface{})
+		att["name"] = v.Name
+		att["image"] = v.Image
+		att["command"] = v.Command
+		att["args"] = v.Args
+		att["working_dir"] = v.WorkingDir
+		att["env"] = k8s.FlattenEnv(v.Env)
+		att["env_from"] = k8s.FlattenEnvFrom(v.EnvFrom)
+		att["volume_mounts"] = flattenTektonVolumeMounts(v.VolumeMounts)
+		att["volume_devices"] = k8s.FlattenVolumeDevices(v.VolumeDevices)
+		att["image_pull_policy"] = string(v.ImagePullPolicy)
+		att["ports"] = k8s.FlattenPorts(v.Ports)
+		att["liveness_probe"] = k8s.FlattenProbe(v.LivenessProbe)
+		att["readiness_probe"] = k8s.FlattenProbe(v.ReadinessProbe)
+		att["startup_probe"] = k8s.FlattenProbe(v.StartupProbe)
+		att["lifecycle"] = k8s.FlattenLifecycle(v.Lifecycle)
+		att["compute_resources"] = flattenTektonComputeResources(v.ComputeResources)
+		att["security_context"] = k8s.FlattenSecurityContext(v.SecurityContext)
+		att["termination_message_path"] = v.TerminationMessagePath
+		att["termination_message_policy"] = string(v.TerminationMessagePolicy)
+		att["stdin"] = v.Stdin
+		att["tty"] = v.TTY
+		att["script"] = v.Script
+		att["workspaces"] = flattenTektonWorkspaceUsages(v.Workspaces)
+
+		result = append(result, att)
+	}
+
+	return result
+}
@@ -5,18 +5,19 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/rh01/terraform-provider-tekton/tekton/schema/k8s"
+	"github.com/rh01/terraform-provider-tekton/tekton/utils"
 	tektonapiv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 )
 
-func tektonTaskSpecFields() map[string]*schema.Schema {
+func TektonTaskSpecFields() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
 		"params": {
 			Type:        schema.TypeList,
 			Description: "Params is a list of input parameters required to run the task. Params must be supplied as inputs in TaskRuns unless they declare a default value.",
 			Optional:    true,
-			// MaxItems:    1,
 			Elem: &schema.Resource{
-				Schema: tektonParamSpecFields(),
+				Schema: TektonParamSpecFields("Task"),
 			},
 		},
 		"display_name": {
@@ -37,14 +38,12 @@ func tektonTaskSpecFields() map[string]*schema.Schema {
 				Schema: tektonStepFields(),
 			},
 		},
-		// "volumes": {
-		// 	Type:        schema.TypeList,
-		// 	Description: "Volumes is a collection of volumes that are available to mount into the steps of the build.",
-		// 	Optional:    true,
-		// 	Elem: &schema.Resource{
-		// 		Schema: tektonVolumeFields(),
-		// 	},
-		// },
+		"volumes": {
+			Type:        schema.TypeList,
+			Description: "Volumes is a collection of volumes that are available to mount into the steps of the build.",
+			Optional:    true,
+			Elem:        k8s.VolumeSchema(),
+		},
 		"step_template": {
 			Type:        schema.TypeList,
 			Description: "StepTemplate can be used as the basis for all step containers within the Task, so that the steps inherit settings on the base container.",
@@ -70,19 +69,19 @@ func tektonTaskSpecFields() map[string]*schema.Schema {
 				Schema: tektonWorkspaceDeclarationFields(),
 			},
 		},
-		// "results": {
-		// 	Type:        schema.TypeList,
-		// 	Description: "Results are values that this Task can output",
-		// 	Optional:    true,
-		// 	Elem: &schema.Resource{
-		// 		Schema: tektonTaskResultFields(),
-		// 	},
-		// },
+		"results": {
+			Type:        schema.TypeList,
+			Description: "Results are values that this Task can output",
+			Optional:    true,
+			Elem: &schema.Resource{
+				Schema: tektonTaskResultFields(),
+			},
+		},
 	}
 }
 
 func tektonTaskSpecSchema() *schema.Schema {
-	fields := tektonTaskSpecFields()
+	fields := TektonTaskSpecFields()
 
 	return &schema.Schema{
 		Type:        schema.TypeList,
@@ -96,7 +95,8 @@ func tektonTaskSpecSchema() *schema.Schema {
 
 }
 
-func tektonParamSpecFields() map[string]*schema.Schema {
+// TektonParamSpecFields returns the fields of a parameter declared by a Task or a Pipeline, owner.
+func TektonParamSpecFields(owner string) map[string]*schema.Schema {
 	return map[string]*schema.Schema{
 		"name": {
 			Type:        schema.TypeString,
@@ -107,6 +107,7 @@ func tektonParamSpecFields() map[string]*schema.Schema {
 			Type:         schema.TypeString,
 			Description:  "Type is the user-specified type of the parameter. The possible types are currently string, array and object, and string is the default.",
 			Optional:     true,
+			Computed:     true,
 			ValidateFunc: validation.StringInSlice([]string{"string", "array", "object"}, false),
 		},
 		"description": {
@@ -116,41 +117,33 @@ func tektonParamSpecFields() map[string]*schema.Schema {
 		},
 		"properties": {
 			Type:        schema.TypeMap,
-			Description: "Properties is the JSON Schema properties to support key-value pairs parameter.",
+			Description: "Properties is the JSON Schema properties to support key-value pairs parameter, as a map of key name to its type.",
 			Optional:    true,
-			Elem: &schema.Resource{
-				Schema: tektonPropertySpecFields(),
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringInSlice([]string{"string", "array", "object"}, false),
 			},
 		},
 		"default": {
 			Type:        schema.TypeList,
-			Description: "Default is the value a parameter takes if no input value is supplied. If default is set, a Task may be executed without a supplied value for the parameter.",
+			Description: fmt.Sprintf("Default is the value a parameter takes if no input value is supplied. If default is set, a %s may be executed without a supplied value for the parameter.", owner),
 			Optional:    true,
 			MaxItems:    1,
 			Elem: &schema.Resource{
-				Schema: tektonParamValueFields(),
+				Schema: TektonParamValueFields(),
 			},
 		},
 	}
 }
 
-func tektonPropertySpecFields() map[string]*schema.Schema {
+// TektonParamValueFields returns the fields of the value of a parameter or a result, typed string, array or object.
+func TektonParamValueFields() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
 		"type": {
 			Type:         schema.TypeString,
-			Description:  "Type is the user-specified type of the parameter. The possible types are currently string, array and object, and string is the default.",
-			Optional:     true,
-			ValidateFunc: validation.StringInSlice([]string{"string", "array", "object"}, false),
-		},
-	}
-}
-
-func tektonParamValueFields() map[string]*schema.Schema {
-	return map[string]*schema.Schema{
-		"type": {
-			Type:         schema.TypeString,
-			Description:  "Type is the user-specified type of the parameter. The possible types are currently string, array and object, and string is the default.",
+			Description:  "Type is the user-specified type of the parameter. The possible types are currently string, array and object. When omitted it is inferred from the value that is set.",
 			Optional:     true,
+			Computed:     true,
 			ValidateFunc: validation.StringInSlice([]string{"string", "array", "object"}, false),
 		},
 		"string_val": {
@@ -177,20 +170,185 @@ func tektonParamValueFields() map[string]*schema.Schema {
 	}
 }
 
-func expandTektonTaskSpec(task []interface{}) (tektonapiv1.TaskSpec, error) {
+func ExpandTektonTaskSpec(task []interface{}) (tektonapiv1.TaskSpec, error) {
 	result := tektonapiv1.TaskSpec{}
 
 	if len(task) == 0 || task[0] == nil {
 		return result, nil
 	}
 
-	_ = task[0].(map[string]interface{})
+	in := task[0].(map[string]interface{})
+
+	if v, ok := in["display_name"].(string); ok {
+		result.DisplayName = v
+	}
+	if v, ok := in["description"].(string); ok {
+		result.Description = v
+	}
+	if v, ok := in["params"].([]interface{}); ok {
+		result.Params = ExpandTektonParamSpecs(v)
+	}
+	if v, ok := in["steps"].([]interface{}); ok {
+		steps, err := expandTektonSteps(v)
+		if err != nil {
+			return result, err
+		}
+		result.Steps = steps
+	}
+	if v, ok := in["volumes"].([]interface{}); ok {
+		volumes, err := k8s.ExpandVolumes(v)
+		if err != nil {
+			return result, err
+		}
+		result.Volumes = volumes
+	}
+	if v, ok := in["step_template"].([]interface{}); ok {
+		stepTemplate, err := expandTektonStepTemplate(v)
+		if err != nil {
+			return result, err
+		}
+		result.StepTemplate = stepTemplate
+	}
+	if v, ok := in["sidecars"].([]interface{}); ok {
+		sidecars, err := expandTektonSidecars(v)
+		if err != nil {
+			return result, err
+		}
+		result.Sidecars = sidecars
+	}
+	if v, ok := in["workspaces"].([]interface{}); ok {
+		result.Workspaces = expandTektonWorkspaceDeclarations(v)
+	}
+	if v, ok := in["results"].([]interface{}); ok {
+		result.Results = expandTektonTaskResults(v)
+	}
 
 	return result, nil
 }
 
-func flattenTektonTaskSpec(in tektonapiv1.TaskSpec) []interface{} {
+func ExpandTektonParamSpecs(in []interface{}) tektonapiv1.ParamSpecs {
+	if len(in) == 0 {
+		return nil
+	}
+
+	result := make(tektonapiv1.ParamSpecs, 0, len(in))
+	for _, param := range in {
+		p, ok := param.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		spec := tektonapiv1.ParamSpec{
+			Name:        p["name"].(string),
+			Type:        tektonapiv1.ParamType(p["type"].(string)),
+			Description: p["description"].(string),
+			Properties:  expandTektonPropertySpecs(p["properties"].(map[string]interface{})),
+			Default:     ExpandTektonParamValue(p["default"].([]interface{})),
+		}
+		if spec.Type == "" && spec.Default != nil {
+			spec.Type = spec.Default.Type
+		}
+		result = append(result, spec)
+	}
+
+	return result
+}
+
+func expandTektonPropertySpecs(in map[string]interface{}) map[string]tektonapiv1.PropertySpec {
+	if len(in) == 0 {
+		return nil
+	}
+
+	result := make(map[string]tektonapiv1.PropertySpec, len(in))
+	for k, v := range in {
+		result[k] = tektonapiv1.PropertySpec{Type: tektonapiv1.ParamType(v.(string))}
+	}
+
+	return result
+}
+
+// ExpandTektonParamValue infers the type of the value from the populated field when it is
+// not set explicitly, as ParamValue can not be marshalled without a type.
+func ExpandTektonParamValue(value []interface{}) *tektonapiv1.ParamValue {
+	if len(value) == 0 || value[0] == nil {
+		return nil
+	}
+
+	v := value[0].(map[string]interface{})
+
+	result := &tektonapiv1.ParamValue{
+		Type:      tektonapiv1.ParamType(v["type"].(string)),
+		StringVal: v["string_val"].(string),
+		ArrayVal:  utils.ExpandStringSlice(v["array_val"].([]interface{})),
+		ObjectVal: utils.ExpandStringMap(v["object_val"].(map[string]interface{})),
+	}
+	if result.Type == "" {
+		switch {
+		case len(result.ArrayVal) > 0:
+			result.Type = tektonapiv1.ParamTypeArray
+		case len(result.ObjectVal) > 0:
+			result.Type = tektonapiv1.ParamTypeObject
+		default:
+			result.Type = tektonapiv1.ParamTypeString
+		}
+	}
+
+	return result
+}
+
+func FlattenTektonTaskSpec(in tektonapiv1.TaskSpec) []interface{} {
+	att := make(map[string]interface{})
+
+	att["display_name"] = in.DisplayName
+	att["description"] = in.Description
+	att["params"] = FlattenTektonParamSpecs(in.Params)
+	att["steps"] = flattenTektonSteps(in.Steps)
+	att["volumes"] = k8s.FlattenVolumes(in.Volumes)
+	if in.StepTemplate != nil {
+		att["step_template"] = flattenTektonStepTemplate(*in.StepTemplate)
+	}
+	att["sidecars"] = flattenTektonSidecars(in.Sidecars)
+	att["workspaces"] = flattenTektonWorkspaceDeclarations(in.Workspaces)
+	att["results"] = flattenTektonTaskResults(in.Results)
+
+	return []interface{}{att}
+}
+
+func FlattenTektonParamSpecs(in tektonapiv1.ParamSpecs) []interface{} {
+	result := make([]interface{}, 0, len(in))
+
+	for _, v := range in {
+		att := make(map[string]interface{})
+		att["name"] = v.Name
+		att["type"] = string(v.Type)
+		att["description"] = v.Description
+		att["properties"] = flattenTektonPropertySpecs(v.Properties)
+		att["default"] = FlattenTektonParamValue(v.Default)
+
+		result = append(result, att)
+	}
+
+	return result
+}
+
+func flattenTektonPropertySpecs(in map[string]tektonapiv1.PropertySpec) map[string]interface{} {
+	result := make(map[string]interface{}, len(in))
+	for k, v := range in {
+		result[k] = string(v.Type)
+	}
+
+	return result
+}
+
+func FlattenTektonParamValue(in *tektonapiv1.ParamValue) []interface{} {
+	if in == nil {
+		return []interface{}{}
+	}
+
 	att := make(map[string]interface{})
+	att["type"] = string(in.Type)
+	att["string_val"] = in.StringVal
+	att["array_val"] = in.ArrayVal
+	att["object_val"] = utils.FlattenStringMap(in.ObjectVal)
 
 	return []interface{}{att}
 }
@@ -0,0 +1,103 @@
+package task
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"k8s.io/apimachinery/pkg/api/equality"
+)
+
+func TestTaskSpecRoundTrip(t *testing.T) {
+	volumeMounts := []interface{}{
+		map[string]interface{}{"name": "source", "mount_path": "/workspace/source", "read_only": true, "sub_path": "src"},
+		map[string]interface{}{"name": "cache", "mount_path": "/cache", "sub_path_expr": "$(POD_NAME)", "mount_propagation": "HostToContainer"},
+	}
+	raw := map[string]interface{}{
+		"metadata": []interface{}{map[string]interface{}{"name": "build", "namespace": "default"}},
+		"spec": []interface{}{map[string]interface{}{
+			"description": "Builds the sources",
+			"params": []interface{}{
+				map[string]interface{}{"name": "revision", "type": "string", "default": []interface{}{map[string]interface{}{"string_val": "main"}}},
+				map[string]interface{}{"name": "flags", "type": "array", "default": []interface{}{map[string]interface{}{"array_val": []interface{}{"-v", "-race"}}}},
+				map[string]interface{}{"name": "target", "type": "object", "properties": map[string]interface{}{"os": "string", "arch": "string"}},
+			},
+			"steps": []interface{}{map[string]interface{}{
+				"name":          "build",
+				"image":         "golang",
+				"command":       []interface{}{"go"},
+				"args":          []interface{}{"build", "./..."},
+				"working_dir":   "/workspace/source",
+				"volume_mounts": volumeMounts,
+				"env": []interface{}{
+					map[string]interface{}{"name": "GOFLAGS", "value": "-mod=vendor"},
+					map[string]interface{}{"name": "TOKEN", "value_from": []interface{}{map[string]interface{}{"secret_key_ref": []interface{}{map[string]interface{}{"name": "git", "key": "token"}}}}},
+				},
+				"compute_resources": []interface{}{map[string]interface{}{"limits": map[string]interface{}{"cpu": "1", "memory": "1Gi"}}},
+				"timeout":           "10m0s",
+				"on_error":          "continue",
+			}},
+			"step_template": []interface{}{map[string]interface{}{
+				"env":           []interface{}{map[string]interface{}{"name": "HOME", "value": "/tekton/home"}},
+				"volume_mounts": volumeMounts,
+			}},
+			"sidecars": []interface{}{map[string]interface{}{
+				"name":              "registry",
+				"image":             "registry",
+				"image_pull_policy": "Always",
+				"ports":             []interface{}{map[string]interface{}{"container_port": 5000}},
+				"readiness_probe":   []interface{}{map[string]interface{}{"tcp_socket": []interface{}{map[string]interface{}{"port": "5000"}}}},
+				"lifecycle": []interface{}{map[string]interface{}{
+					"pre_stop": []interface{}{map[string]interface{}{"exec": []interface{}{map[string]interface{}{"command": []interface{}{"registry", "garbage-collect"}}}}},
+				}},
+				"termination_message_path":   "/tmp/termination-log",
+				"termination_message_policy": "FallbackToLogsOnError",
+				"stdin":                      true,
+				"tty":                        true,
+				"volume_mounts":              volumeMounts,
+			}},
+			"volumes": []interface{}{
+				map[string]interface{}{"name": "source", "empty_dir": []interface{}{map[string]interface{}{"medium": "Memory"}}},
+				map[string]interface{}{"name": "cache", "config_map": []interface{}{map[string]interface{}{"name": "cache"}}},
+			},
+			"results": []interface{}{
+				map[string]interface{}{"name": "digest", "type": "string", "description": "Digest of the image"},
+				map[string]interface{}{"name": "tags", "type": "array"},
+				map[string]interface{}{"name": "image", "type": "object", "properties": map[string]interface{}{"url": "string", "digest": "string"}},
+			},
+		}},
+	}
+
+	expanded, err := FromResourceData(schema.TestResourceDataRaw(t, TektonTaskFields(), raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(expanded.Spec.Params) != 3 || len(expanded.Spec.Results) != 3 || len(expanded.Spec.Volumes) != 2 || expanded.Spec.StepTemplate == nil {
+		t.Fatalf("Expected the whole task spec to be expanded, given: %#v", expanded.Spec)
+	}
+
+	resourceData := schema.TestResourceDataRaw(t, TektonTaskFields(), map[string]interface{}{})
+	if err := ToResourceData(*expanded, resourceData); err != nil {
+		t.Fatal(err)
+	}
+	given, err := FromResourceData(resourceData)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !equality.Semantic.DeepEqual(expanded.Spec, given.Spec) {
+		expected, _ := json.Marshal(expanded.Spec)
+		actual, _ := json.Marshal(given.Spec)
+		t.Fatalf("Task specs don't match.\nExpected: %s\nGiven:    %s\n", expected, actual)
+	}
+
+	sidecar := given.Spec.Sidecars[0]
+	if sidecar.Lifecycle == nil || sidecar.Lifecycle.PreStop == nil || sidecar.TerminationMessagePolicy != "FallbackToLogsOnError" || !sidecar.Stdin || !sidecar.TTY {
+		t.Fatalf("Expected the sidecar fields to be kept, given: %#v", sidecar)
+	}
+	mount := given.Spec.Steps[0].VolumeMounts[1]
+	if mount.SubPathExpr != "$(POD_NAME)" || mount.MountPropagation == nil || *mount.MountPropagation != "HostToContainer" {
+		t.Fatalf("Expected the volume mount fields to be kept, given: %#v", mount)
+	}
+}
@@ -1,7 +1,15 @@
 package task
 
 import (
+	"time"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/rh01/terraform-provider-tekton/tekton/schema/k8s"
+	"github.com/rh01/terraform-provider-tekton/tekton/utils"
+	tektonapiv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func tektonStepFields() map[string]*schema.Schema {
@@ -37,64 +45,35 @@ func tektonStepFields() map[string]*schema.Schema {
 			Description: "Working directory to use when executing the step",
 			Optional:    true,
 		},
-		"env": {
-			Type:        schema.TypeList,
-			Description: "Environment variables to set for the step",
-			Optional:    true,
-			Elem: &schema.Resource{
-				Schema: map[string]*schema.Schema{
-					"name": {
-						Type:        schema.TypeString,
-						Description: "Name of the environment variable",
-						Required:    true,
-					},
-					"value": {
-						Type:        schema.TypeString,
-						Description: "Value of the environment variable",
-						Required:    true,
-					},
-				},
-			},
-		},
+		"env":      k8s.EnvSchema("Environment variables to set for the step"),
+		"env_from": k8s.EnvFromSchema("ConfigMaps and Secrets whose keys are set as environment variables of the step"),
 		"volume_mounts": {
 			Type:        schema.TypeList,
 			Description: "Volume mounts for the step",
 			Optional:    true,
 			Elem: &schema.Resource{
-				Schema: map[string]*schema.Schema{
-					"name": {
-						Type:        schema.TypeString,
-						Description: "Name of the volume mount",
-						Required:    true,
-					},
-					"mount_path": {
-						Type:        schema.TypeString,
-						Description: "Path to mount the volume at",
-						Required:    true,
-					},
-					"read_only": {
-						Type:        schema.TypeBool,
-						Description: "Whether the volume should be mounted read-only",
-						Optional:    true,
-					},
-				},
+				Schema: volumeMountFields(),
 			},
 		},
+		"volume_devices": k8s.VolumeDevicesSchema("Block devices to map into the step"),
 		"image_pull_policy": {
 			Type:        schema.TypeString,
 			Description: "Image pull policy for the step",
 			Optional:    true,
 		},
-
+		"compute_resources": k8s.ResourceRequirementsSchema("Compute resources required by the step"),
+		"security_context":  k8s.SecurityContextSchema("Security options the step should run with"),
 		"script": {
 			Type:        schema.TypeString,
 			Description: "Contents of an executable file to execute",
 			Optional:    true,
 		},
 		"timeout": {
-			Type:        schema.TypeString,
-			Description: "Time after which the step times out",
-			Optional:    true,
+			Type:             schema.TypeString,
+			Description:      "Time after which the step times out",
+			Optional:         true,
+			ValidateFunc:     utils.ValidateDuration,
+			DiffSuppressFunc: utils.SuppressEquivalentDuration,
 		},
 		"workspaces": {
 			Type:        schema.TypeList,
@@ -104,42 +83,76 @@ func tektonStepFields() map[string]*schema.Schema {
 				Schema: workspaceUsageFields(),
 			},
 		},
-		// "on_error": {
-		// 	Type:        schema.TypeString,
-		// 	Description: "Exiting behavior of a container on error",
-		// 	Optional:    true,
-		// },
-		// "stdout_config": {
-		// 	Type:        schema.TypeList,
-		// 	Description: "Configuration for the stdout stream of the step",
-		// 	Optional:    true,
-		// 	Elem: &schema.Resource{
-		// 		Schema: stepOutputConfigFields(),
-		// 	},
-		// },
-		// "stderr_config": {
-		// 	Type:        schema.TypeList,
-		// 	Description: "Configuration for the stderr stream of the step",
-		// 	Optional:    true,
-		// 	Elem: &schema.Resource{
-		// 		Schema: stepOutputConfigFields(),
-		// 	},
-		// },
+		"on_error": {
+			Type:         schema.TypeString,
+			Description:  "Exiting behavior of a container on error, either stopAndFail or continue",
+			Optional:     true,
+			ValidateFunc: validation.StringInSlice([]string{string(tektonapiv1.StopAndFail), string(tektonapiv1.Continue)}, false),
+		},
+		"stdout_config": {
+			Type:        schema.TypeList,
+			Description: "Configuration for the stdout stream of the step",
+			Optional:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: stepOutputConfigFields(),
+			},
+		},
+		"stderr_config": {
+			Type:        schema.TypeList,
+			Description: "Configuration for the stderr stream of the step",
+			Optional:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: stepOutputConfigFields(),
+			},
+		},
 	}
 }
 
 func stepOutputConfigFields() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
-		"resource": {
+		"path": {
+			Type:        schema.TypeString,
+			Description: "Path to duplicate the stream to on the container's local filesystem",
+			Optional:    true,
+		},
+	}
+}
+
+func volumeMountFields() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:        schema.TypeString,
+			Description: "Name of the volume mount",
+			Required:    true,
+		},
+		"mount_path": {
+			Type:        schema.TypeString,
+			Description: "Path to mount the volume at",
+			Required:    true,
+		},
+		"read_only": {
+			Type:        schema.TypeBool,
+			Description: "Whether the volume should be mounted read-only",
+			Optional:    true,
+		},
+		"sub_path": {
 			Type:        schema.TypeString,
-			Description: "Name of the resource to store the stream in",
+			Description: "Path within the volume to mount instead of its root",
 			Optional:    true,
 		},
-		"workspace": {
+		"sub_path_expr": {
 			Type:        schema.TypeString,
-			Description: "Name of the workspace to store the stream in",
+			Description: "Path within the volume to mount instead of its root, in which the $(VAR_NAME) references to environment variables are expanded",
 			Optional:    true,
 		},
+		"mount_propagation": {
+			Type:         schema.TypeString,
+			Description:  "How mounts are propagated from the host to the container and the other way around, one of None, HostToContainer or Bidirectional",
+			Optional:     true,
+			ValidateFunc: validation.StringInSlice([]string{string(corev1.MountPropagationNone), string(corev1.MountPropagationHostToContainer), string(corev1.MountPropagationBidirectional)}, false),
+		},
 	}
 }
 
@@ -153,6 +166,227 @@ func workspaceUsageFields() map[string]*schema.Schema {
 		"mount_path": {
 			Type:        schema.TypeString,
 			Description: "Path to mount the workspace at",
+			Required:    true,
 		},
 	}
 }
+
+func expandTektonSteps(in []interface{}) ([]tektonapiv1.Step, error) {
+	if len(in) == 0 {
+		return nil, nil
+	}
+
+	result := make([]tektonapiv1.Step, 0, len(in))
+	for _, s := range in {
+		m, ok := s.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		step := tektonapiv1.Step{
+			Name:            m["name"].(string),
+			Image:           m["image"].(string),
+			Command:         utils.ExpandStringSlice(m["command"].([]interface{})),
+			Args:            utils.ExpandStringSlice(m["args"].([]interface{})),
+			WorkingDir:      m["working_dir"].(string),
+			VolumeMounts:    expandTektonVolumeMounts(m["volume_mounts"].([]interface{})),
+			VolumeDevices:   k8s.ExpandVolumeDevices(m["volume_devices"].([]interface{})),
+			ImagePullPolicy: corev1.PullPolicy(m["image_pull_policy"].(string)),
+			Script:          m["script"].(string),
+			Workspaces:      expandTektonWorkspaceUsages(m["workspaces"].([]interface{})),
+			OnError:         tektonapiv1.OnErrorType(m["on_error"].(string)),
+			StdoutConfig:    expandTektonStepOutputConfig(m["stdout_config"].([]interface{})),
+			StderrConfig:    expandTektonStepOutputConfig(m["stderr_config"].([]interface{})),
+		}
+		if err := expandTektonContainerFields(m, &step.Env, &step.EnvFrom, &step.ComputeResources, &step.SecurityContext); err != nil {
+			return result, err
+		}
+		if v := m["timeout"].(string); v != "" {
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				return result, err
+			}
+			step.Timeout = &metav1.Duration{Duration: d}
+		}
+		result = append(result, step)
+	}
+
+	return result, nil
+}
+
+// expandTektonContainerFields expands the container fields shared by steps, the step template and
+// sidecars, which can't be set in their struct literal because their expansion may fail.
+func expandTektonContainerFields(m map[string]interface{}, env *[]corev1.EnvVar, envFrom *[]corev1.EnvFromSource, resources *corev1.ResourceRequirements, securityContext **corev1.SecurityContext) error {
+	var err error
+	if *env, err = k8s.ExpandEnv(m["env"].([]interface{})); err != nil {
+		return err
+	}
+	if *envFrom, err = k8s.ExpandEnvFrom(m["env_from"].([]interface{})); err != nil {
+		return err
+	}
+	r, err := k8s.ExpandResourceRequirements(m["compute_resources"].([]interface{}))
+	if err != nil {
+		return err
+	}
+	if r != nil {
+		*resources = *r
+	}
+	*securityContext, err = k8s.ExpandSecurityContext(m["security_context"].([]interface{}))
+	return err
+}
+
+func expandTektonVolumeMounts(in []interface{}) []corev1.VolumeMount {
+	if len(in) == 0 {
+		return nil
+	}
+
+	result := make([]corev1.VolumeMount, 0, len(in))
+	for _, vm := range in {
+		m, ok := vm.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		mount := corev1.VolumeMount{
+			Name:      m["name"].(string),
+			MountPath: m["mount_path"].(string),
+		}
+		if v, ok := m["read_only"].(bool); ok {
+			mount.ReadOnly = v
+		}
+		if v, ok := m["sub_path"].(string); ok {
+			mount.SubPath = v
+		}
+		if v, ok := m["sub_path_expr"].(string); ok {
+			mount.SubPathExpr = v
+		}
+		if v, ok := m["mount_propagation"].(string); ok && v != "" {
+			mp := corev1.MountPropagationMode(v)
+			mount.MountPropagation = &mp
+		}
+		result = append(result, mount)
+	}
+
+	return result
+}
+
+func expandTektonWorkspaceUsages(in []interface{}) []tektonapiv1.WorkspaceUsage {
+	if len(in) == 0 {
+		return nil
+	}
+
+	result := make([]tektonapiv1.WorkspaceUsage, 0, len(in))
+	for _, w := range in {
+		m, ok := w.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		result = append(result, tektonapiv1.WorkspaceUsage{
+			Name:      m["name"].(string),
+			MountPath: m["mount_path"].(string),
+		})
+	}
+
+	return result
+}
+
+func expandTektonStepOutputConfig(in []interface{}) *tektonapiv1.StepOutputConfig {
+	if len(in) == 0 || in[0] == nil {
+		return nil
+	}
+
+	m := in[0].(map[string]interface{})
+
+	return &tektonapiv1.StepOutputConfig{
+		Path: m["path"].(string),
+	}
+}
+
+func flattenTektonSteps(in []tektonapiv1.Step) []interface{} {
+	result := make([]interface{}, 0, len(in))
+
+	for _, v := range in {
+		att := make(map[string]interface{})
+		att["name"] = v.Name
+		att["image"] = v.Image
+		att["command"] = v.Command
+		att["args"] = v.Args
+		att["working_dir"] = v.WorkingDir
+		att["env"] = k8s.FlattenEnv(v.Env)
+		att["env_from"] = k8s.FlattenEnvFrom(v.EnvFrom)
+		att["volume_mounts"] = flattenTektonVolumeMounts(v.VolumeMounts)
+		att["volume_devices"] = k8s.FlattenVolumeDevices(v.VolumeDevices)
+		att["image_pull_policy"] = string(v.ImagePullPolicy)
+		att["compute_resources"] = flattenTektonComputeResources(v.ComputeResources)
+		att["security_context"] = k8s.FlattenSecurityContext(v.SecurityContext)
+		att["script"] = v.Script
+		if v.Timeout != nil {
+			att["timeout"] = v.Timeout.Duration.String()
+		}
+		att["workspaces"] = flattenTektonWorkspaceUsages(v.Workspaces)
+		att["on_error"] = string(v.OnError)
+		att["stdout_config"] = flattenTektonStepOutputConfig(v.StdoutConfig)
+		att["stderr_config"] = flattenTektonStepOutputConfig(v.StderrConfig)
+
+		result = append(result, att)
+	}
+
+	return result
+}
+
+func flattenTektonComputeResources(in corev1.ResourceRequirements) []interface{} {
+	if len(in.Limits) == 0 && len(in.Requests) == 0 {
+		return []interface{}{}
+	}
+
+	return k8s.FlattenResourceRequirements(&in)
+}
+
+func flattenTektonVolumeMounts(in []corev1.VolumeMount) []interface{} {
+	result := make([]interface{}, 0, len(in))
+
+	for _, v := range in {
+		att := make(map[string]interface{})
+		att["name"] = v.Name
+		att["mount_path"] = v.MountPath
+		if v.ReadOnly {
+			att["read_only"] = v.ReadOnly
+		}
+		if v.SubPath != "" {
+			att["sub_path"] = v.SubPath
+		}
+		if v.SubPathExpr != "" {
+			att["sub_path_expr"] = v.SubPathExpr
+		}
+		if v.MountPropagation != nil {
+			att["mount_propagation"] = string(*v.MountPropagation)
+		}
+
+		result = append(result, att)
+	}
+
+	return result
+}
+
+func flattenTektonWorkspaceUsages(in []tektonapiv1.WorkspaceUsage) []interface{} {
+	result := make([]interface{}, 0, len(in))
+
+	for _, v := range in {
+		att := make(map[string]interface{})
+		att["name"] = v.Name
+		att["mount_path"] = v.MountPath
+
+		result = append(result, att)
+	}
+
+	return result
+}
+
+func flattenTektonStepOutputConfig(in *tektonapiv1.StepOutputConfig) []interface{} {
+	if in == nil {
+		return []interface{}{}
+	}
+
+	att := make(map[string]interface{})
+	att["path"] = in.Path
+
+	return []interface{}{att}
+}
@@ -2,6 +2,10 @@ package task
 
 import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/rh01/terraform-provider-tekton/tekton/schema/k8s"
+	"github.com/rh01/terraform-provider-tekton/tekton/utils"
+	tektonapiv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
 )
 
 func tektonStepTemplateFields() map[string]*schema.Schema {
@@ -32,64 +36,64 @@ func tektonStepTemplateFields() map[string]*schema.Schema {
 			Description: "Working directory to use when executing the step",
 			Optional:    true,
 		},
-		"env": {
-			Type:        schema.TypeList,
-			Description: "Environment variables to set for the step",
-			Optional:    true,
-			Elem: &schema.Resource{
-				Schema: map[string]*schema.Schema{
-					"name": {
-						Type:        schema.TypeString,
-						Description: "Name of the environment variable",
-						Required:    true,
-					},
-					"value": {
-						Type:        schema.TypeString,
-						Description: "Value of the environment variable",
-						Required:    true,
-					},
-				},
-			},
-		},
+		"env":      k8s.EnvSchema("Environment variables to set for the steps"),
+		"env_from": k8s.EnvFromSchema("ConfigMaps and Secrets whose keys are set as environment variables of the steps"),
 		"volume_mounts": {
 			Type:        schema.TypeList,
 			Description: "Volume mounts for the step",
 			Optional:    true,
 			Elem: &schema.Resource{
-				Schema: map[string]*schema.Schema{
-					"name": {
-						Type:        schema.TypeString,
-						Description: "Name of the volume mount",
-						Required:    true,
-					},
-					"mount_path": {
-						Type:        schema.TypeString,
-						Description: "Path to mount the volume at",
-						Required:    true,
-					},
-					"read_only": {
-						Type:        schema.TypeBool,
-						Description: "Whether the volume should be mounted read-only",
-						Optional:    true,
-					},
-				},
+				Schema: volumeMountFields(),
 			},
 		},
+		"volume_devices": k8s.VolumeDevicesSchema("Block devices to map into the steps"),
 		"image_pull_policy": {
 			Type:        schema.TypeString,
 			Description: "Image pull policy for the step",
 			Optional:    true,
 		},
+		"compute_resources": k8s.ResourceRequirementsSchema("Compute resources required by the steps"),
+		"security_context":  k8s.SecurityContextSchema("Security options the steps should run with"),
+	}
+}
 
-		"script": {
-			Type:        schema.TypeString,
-			Description: "Contents of an executable file to execute",
-			Optional:    true,
-		},
-		"timeout": {
-			Type:        schema.TypeString,
-			Description: "Time after which the step times out",
-			Optional:    true,
-		},
+func expandTektonStepTemplate(in []interface{}) (*tektonapiv1.StepTemplate, error) {
+	if len(in) == 0 || in[0] == nil {
+		return nil, nil
+	}
+
+	m := in[0].(map[string]interface{})
+
+	result := &tektonapiv1.StepTemplate{
+		Image:           m["image"].(string),
+		Command:         utils.ExpandStringSlice(m["command"].([]interface{})),
+		Args:            utils.ExpandStringSlice(m["args"].([]interface{})),
+		WorkingDir:      m["working_dir"].(string),
+		VolumeMounts:    expandTektonVolumeMounts(m["volume_mounts"].([]interface{})),
+		VolumeDevices:   k8s.ExpandVolumeDevices(m["volume_devices"].([]interface{})),
+		ImagePullPolicy: corev1.PullPolicy(m["image_pull_policy"].(string)),
+	}
+	if err := expandTektonContainerFields(m, &result.Env, &result.EnvFrom, &result.ComputeResources, &result.SecurityContext); err != nil {
+		return result, err
 	}
+
+	return result, nil
+}
+
+func flattenTektonStepTemplate(in tektonapiv1.StepTemplate) []interface{} {
+	att := make(map[string]interface{})
+
+	att["image"] = in.Image
+	att["command"] = in.Command
+	att["args"] = in.Args
+	att["working_dir"] = in.WorkingDir
+	att["env"] = k8s.FlattenEnv(in.Env)
+	att["env_from"] = k8s.FlattenEnvFrom(in.EnvFrom)
+	att["volume_mounts"] = flattenTektonVolumeMounts(in.VolumeMounts)
+	att["volume_devices"] = k8s.FlattenVolumeDevices(in.VolumeDevices)
+	att["image_pull_policy"] = string(in.ImagePullPolicy)
+	att["compute_resources"] = flattenTektonComputeResources(in.ComputeResources)
+	att["security_context"] = k8s.FlattenSecurityContext(in.SecurityContext)
+
+	return []interface{}{att}
 }
@@ -9,8 +9,9 @@ import (
 
 func TektonTaskFields() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
-		"metadata": k8s.NamespacedMetadataSchema("Task", false),
-		"spec":     tektonTaskSpecSchema(),
+		"metadata":    k8s.NamespacedMetadataSchema("Task", false),
+		"api_version": k8s.APIVersionSchema("Task"),
+		"spec":        tektonTaskSpecSchema(),
 	}
 }
 
@@ -27,7 +28,7 @@ func ExpandTektonTask(tasks []interface{}) (*tektonapiv1.Task, error) {
 		result.ObjectMeta = k8s.ExpandMetadata(v)
 	}
 	if v, ok := in["spec"].([]interface{}); ok {
-		spec, err := expandTektonTaskSpec(v)
+		spec, err := ExpandTektonTaskSpec(v)
 		if err != nil {
 			return result, err
 		}
@@ -41,7 +42,7 @@ func FlattenTektonTask(in tektonapiv1.Task) []interface{} {
 	att := make(map[string]interface{})
 
 	att["metadata"] = k8s.FlattenMetadata(in.ObjectMeta)
-	att["spec"] = flattenTektonTaskSpec(in.Spec)
+	att["spec"] = FlattenTektonTaskSpec(in.Spec)
 
 	return []interface{}{att}
 }
@@ -50,7 +51,7 @@ func FromResourceData(resourceData *schema.ResourceData) (*tektonapiv1.Task, err
 	result := &tektonapiv1.Task{}
 
 	result.ObjectMeta = k8s.ExpandMetadata(resourceData.Get("metadata").([]interface{}))
-	spec, err := expandTektonTaskSpec(resourceData.Get("spec").([]interface{}))
+	spec, err := ExpandTektonTaskSpec(resourceData.Get("spec").([]interface{}))
 	if err != nil {
 		return result, err
 	}
@@ -63,13 +64,31 @@ func ToResourceData(vm tektonapiv1.Task, resourceData *schema.ResourceData) erro
 	if err := resourceData.Set("metadata", k8s.FlattenMetadata(vm.ObjectMeta)); err != nil {
 		return err
 	}
-	if err := resourceData.Set("spec", flattenTektonTaskSpec(vm.Spec)); err != nil {
+	if err := resourceData.Set("spec", FlattenTektonTaskSpec(vm.Spec)); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func AppendPatchOps(keyPrefix, pathPrefix string, resourceData *schema.ResourceData, ops []patch.PatchOperation) patch.PatchOperations {
-	return k8s.AppendPatchOps(keyPrefix+"metadata.0.", pathPrefix+"/metadata/", resourceData, ops)
+func AppendPatchOps(keyPrefix, pathPrefix string, resourceData *schema.ResourceData, ops []patch.PatchOperation) (patch.PatchOperations, error) {
+	ops = k8s.AppendResourceVersionTestOp(keyPrefix+"metadata.0.", pathPrefix+"/metadata/", resourceData, ops)
+	ops = k8s.AppendPatchOps(keyPrefix+"metadata.0.", pathPrefix+"/metadata/", resourceData, ops)
+	if resourceData.HasChange(keyPrefix + "spec") {
+		oldV, newV := resourceData.GetChange(keyPrefix + "spec")
+		oldSpec, err := ExpandTektonTaskSpec(oldV.([]interface{}))
+		if err != nil {
+			return ops, err
+		}
+		newSpec, err := ExpandTektonTaskSpec(newV.([]interface{}))
+		if err != nil {
+			return ops, err
+		}
+		diffOps, err := patch.DiffObjects(pathPrefix+"/spec", oldSpec, newSpec)
+		if err != nil {
+			return ops, err
+		}
+		ops = append(ops, diffOps...)
+	}
+	return ops, nil
 }
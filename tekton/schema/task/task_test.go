@@ -0,0 +1,107 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/rh01/terraform-provider-tekton/tekton/utils/patch"
+)
+
+// testTaskConfig returns the configuration of the task build, with its labels, description and steps.
+func testTaskConfig(labels map[string]interface{}, description string, steps ...interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"metadata": []interface{}{map[string]interface{}{"name": "build", "namespace": "default", "labels": labels}},
+		"spec": []interface{}{map[string]interface{}{
+			"description": description,
+			"steps":       steps,
+		}},
+	}
+}
+
+// testTaskResourceData returns the resource data planning the change of the task from the state
+// oldRaw, read at resourceVersion, to the configuration newRaw.
+func testTaskResourceData(t *testing.T, oldRaw map[string]interface{}, resourceVersion string, newRaw map[string]interface{}) *schema.ResourceData {
+	fields := schema.InternalMap(TektonTaskFields())
+
+	task, err := FromResourceData(schema.TestResourceDataRaw(t, TektonTaskFields(), oldRaw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	task.ResourceVersion = resourceVersion
+	resourceData := schema.TestResourceDataRaw(t, TektonTaskFields(), map[string]interface{}{})
+	if err := ToResourceData(*task, resourceData); err != nil {
+		t.Fatal(err)
+	}
+	resourceData.SetId("default/build")
+	state := resourceData.State()
+
+	diff, err := fields.Diff(context.Background(), state, terraform.NewResourceConfigRaw(newRaw), nil, nil, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := fields.Data(state, diff)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return result
+}
+
+func TestAppendPatchOps(t *testing.T) {
+	build := map[string]interface{}{"name": "build", "image": "golang", "working_dir": "/workspace/source"}
+	test := map[string]interface{}{"name": "test", "image": "golang"}
+
+	testCases := []struct {
+		Old         map[string]interface{}
+		New         map[string]interface{}
+		ExpectedOps patch.PatchOperations
+	}{
+		{
+			Old: testTaskConfig(map[string]interface{}{"app": "build"}, "build", build),
+			New: testTaskConfig(map[string]interface{}{"app": "build"}, "build", build),
+			ExpectedOps: []patch.PatchOperation{
+				&patch.TestOperation{Path: "/metadata/resourceVersion", Value: "7"},
+			},
+		},
+		{
+			Old: testTaskConfig(map[string]interface{}{"app": "build"}, "build", build),
+			New: testTaskConfig(map[string]interface{}{"app": "build", "team": "ci"}, "Builds the sources", build, test),
+			ExpectedOps: []patch.PatchOperation{
+				&patch.TestOperation{Path: "/metadata/resourceVersion", Value: "7"},
+				&patch.AddOperation{Path: "/metadata/labels/team", Value: "ci"},
+				&patch.ReplaceOperation{Path: "/spec/description", Value: "Builds the sources"},
+				&patch.AddOperation{Path: "/spec/steps/1", Value: map[string]interface{}{"name": "test", "image": "golang", "computeResources": map[string]interface{}{}}},
+			},
+		},
+		{
+			Old: testTaskConfig(map[string]interface{}{"app": "build"}, "build", build, test),
+			New: testTaskConfig(map[string]interface{}{}, "build", map[string]interface{}{"name": "build", "image": "golang:1.20"}),
+			ExpectedOps: []patch.PatchOperation{
+				&patch.TestOperation{Path: "/metadata/resourceVersion", Value: "7"},
+				&patch.RemoveOperation{Path: "/metadata/labels/app"},
+				&patch.ReplaceOperation{Path: "/spec/steps/0/image", Value: "golang:1.20"},
+				&patch.RemoveOperation{Path: "/spec/steps/0/workingDir"},
+				&patch.RemoveOperation{Path: "/spec/steps/1"},
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			resourceData := testTaskResourceData(t, tc.Old, "7", tc.New)
+			ops, err := AppendPatchOps("", "", resourceData, []patch.PatchOperation{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			// The test of the resourceVersion comes first, so no change is applied when it fails.
+			if len(ops) == 0 || ops[0].GetPath() != "/metadata/resourceVersion" {
+				t.Fatalf("Expected the patch to start with the test of the resourceVersion, given: %v", ops)
+			}
+			if !tc.ExpectedOps.Equal(ops) {
+				t.Fatalf("Operations don't match.\nExpected: %v\nGiven:    %v\n", tc.ExpectedOps, ops)
+			}
+		})
+	}
+}
@@ -1,6 +1,9 @@
 package task
 
-import "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	tektonapiv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+)
 
 func tektonWorkspaceDeclarationFields() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
@@ -31,3 +34,43 @@ func tektonWorkspaceDeclarationFields() map[string]*schema.Schema {
 		},
 	}
 }
+
+func expandTektonWorkspaceDeclarations(in []interface{}) []tektonapiv1.WorkspaceDeclaration {
+	if len(in) == 0 {
+		return nil
+	}
+
+	result := make([]tektonapiv1.WorkspaceDeclaration, 0, len(in))
+	for _, w := range in {
+		m, ok := w.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		result = append(result, tektonapiv1.WorkspaceDeclaration{
+			Name:        m["name"].(string),
+			Description: m["description"].(string),
+			MountPath:   m["mount_path"].(string),
+			ReadOnly:    m["read_only"].(bool),
+			Optional:    m["optional"].(bool),
+		})
+	}
+
+	return result
+}
+
+func flattenTektonWorkspaceDeclarations(in []tektonapiv1.WorkspaceDeclaration) []interface{} {
+	result := make([]interface{}, 0, len(in))
+
+	for _, v := range in {
+		att := make(map[string]interface{})
+		att["name"] = v.Name
+		att["description"] = v.Description
+		att["mount_path"] = v.MountPath
+		att["read_only"] = v.ReadOnly
+		att["optional"] = v.Optional
+
+		result = append(result, att)
+	}
+
+	return result
+}
@@ -0,0 +1,74 @@
+package task_run
+
+import (
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+// TektonRunConditionFields describe a condition reported in the status of a TaskRun or PipelineRun.
+func TektonRunConditionFields() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"type": {
+			Type:        schema.TypeString,
+			Description: "Type of the condition, such as Succeeded.",
+			Computed:    true,
+		},
+		"status": {
+			Type:        schema.TypeString,
+			Description: "Status of the condition: True, False or Unknown.",
+			Computed:    true,
+		},
+		"reason": {
+			Type:        schema.TypeString,
+			Description: "Reason is a one-word CamelCase reason for the condition's last transition.",
+			Computed:    true,
+		},
+		"message": {
+			Type:        schema.TypeString,
+			Description: "Message is a human-readable message indicating details about the transition.",
+			Computed:    true,
+		},
+		"last_transition_time": {
+			Type:        schema.TypeString,
+			Description: "LastTransitionTime is the last time the condition transitioned from one status to another.",
+			Computed:    true,
+		},
+	}
+}
+
+func FlattenTektonRunConditions(in duckv1.Conditions) []interface{} {
+	result := make([]interface{}, 0, len(in))
+
+	for _, v := range in {
+		att := make(map[string]interface{})
+		att["type"] = string(v.Type)
+		att["status"] = string(v.Status)
+		att["reason"] = v.Reason
+		att["message"] = v.Message
+		if !v.LastTransitionTime.Inner.IsZero() {
+			att["last_transition_time"] = v.LastTransitionTime.Inner.UTC().Format(time.RFC3339)
+		}
+
+		result = append(result, att)
+	}
+
+	return result
+}
+
+// FlattenTektonRunSucceeded reports whether the Succeeded condition of a run is True.
+func FlattenTektonRunSucceeded(in duckv1.Status) bool {
+	return in.GetCondition(apis.ConditionSucceeded).IsTrue()
+}
+
+// FlattenTektonRunTime formats a status timestamp as RFC 3339, or returns an empty string when it is not set.
+func FlattenTektonRunTime(in *metav1.Time) string {
+	if in == nil || in.IsZero() {
+		return ""
+	}
+
+	return in.UTC().Format(time.RFC3339)
+}
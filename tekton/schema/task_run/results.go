@@ -0,0 +1,138 @@
+package task_run
+
+import (
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	tektonapiv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+)
+
+// TektonRunResultFields are the computed results written out by a TaskRun or PipelineRun.
+func TektonRunResultFields() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"results": {
+			Type:        schema.TypeMap,
+			Description: "Results written out by the run, keyed by name. String results are set as is, array and object results are JSON encoded.",
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"typed_results": {
+			Type:        schema.TypeList,
+			Description: "Results written out by the run, with their type and typed value.",
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: tektonRunTypedResultFields(),
+			},
+		},
+	}
+}
+
+func tektonRunTypedResultFields() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:        schema.TypeString,
+			Description: "Name of the result.",
+			Computed:    true,
+		},
+		"type": {
+			Type:        schema.TypeString,
+			Description: "Type of the result: string, array or object.",
+			Computed:    true,
+		},
+		"string_value": {
+			Type:        schema.TypeString,
+			Description: "Value of a string result.",
+			Computed:    true,
+		},
+		"array_value": {
+			Type:        schema.TypeList,
+			Description: "Value of an array result.",
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"object_value": {
+			Type:        schema.TypeMap,
+			Description: "Value of an object result.",
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+	}
+}
+
+// SetTektonTaskRunResults sets the results and typed_results attributes from the results of a TaskRun.
+func SetTektonTaskRunResults(resourceData *schema.ResourceData, in []tektonapiv1.TaskRunResult) error {
+	results := make(map[string]interface{}, len(in))
+	typedResults := make([]interface{}, 0, len(in))
+
+	for _, r := range in {
+		att, err := flattenTektonRunResult(r.Name, r.Value, results)
+		if err != nil {
+			return err
+		}
+		typedResults = append(typedResults, att)
+	}
+
+	return setTektonRunResults(resourceData, results, typedResults)
+}
+
+// SetTektonPipelineRunResults sets the results and typed_results attributes from the results of a
+// PipelineRun.
+func SetTektonPipelineRunResults(resourceData *schema.ResourceData, in []tektonapiv1.PipelineRunResult) error {
+	results := make(map[string]interface{}, len(in))
+	typedResults := make([]interface{}, 0, len(in))
+
+	for _, r := range in {
+		att, err := flattenTektonRunResult(r.Name, r.Value, results)
+		if err != nil {
+			return err
+		}
+		typedResults = append(typedResults, att)
+	}
+
+	return setTektonRunResults(resourceData, results, typedResults)
+}
+
+// flattenTektonRunResult adds the result name to results, JSON encoded unless it is a string, and
+// returns its typed result.
+func flattenTektonRunResult(name string, value tektonapiv1.ResultValue, results map[string]interface{}) (map[string]interface{}, error) {
+	att := map[string]interface{}{
+		"name": name,
+		"type": string(value.Type),
+	}
+
+	switch value.Type {
+	case tektonapiv1.ParamTypeArray:
+		data, err := json.Marshal(value.ArrayVal)
+		if err != nil {
+			return nil, err
+		}
+		results[name] = string(data)
+		att["array_value"] = value.ArrayVal
+	case tektonapiv1.ParamTypeObject:
+		data, err := json.Marshal(value.ObjectVal)
+		if err != nil {
+			return nil, err
+		}
+		results[name] = string(data)
+		att["object_value"] = value.ObjectVal
+	default:
+		results[name] = value.StringVal
+		att["type"] = string(tektonapiv1.ParamTypeString)
+		att["string_value"] = value.StringVal
+	}
+
+	return att, nil
+}
+
+func setTektonRunResults(resourceData *schema.ResourceData, results map[string]interface{}, typedResults []interface{}) error {
+	if err := resourceData.Set("results", results); err != nil {
+		return err
+	}
+	return resourceData.Set("typed_results", typedResults)
+}
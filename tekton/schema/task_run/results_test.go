@@ -0,0 +1,108 @@
+package task_run
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	tektonapiv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+)
+
+func TestSetTektonTaskRunResults(t *testing.T) {
+	testCases := []struct {
+		Results         []tektonapiv1.TaskRunResult
+		ExpectedResults map[string]interface{}
+		// ExpectedTyped are the typed results, with the attributes of their value only.
+		ExpectedTyped []interface{}
+	}{
+		{
+			Results:         nil,
+			ExpectedResults: map[string]interface{}{},
+			ExpectedTyped:   []interface{}{},
+		},
+		{
+			Results: []tektonapiv1.TaskRunResult{
+				{Name: "digest", Type: tektonapiv1.ResultsTypeString, Value: *tektonapiv1.NewStructuredValues("sha256:0123")},
+				// Results written by older versions of Tekton have no type.
+				{Name: "commit", Value: tektonapiv1.ResultValue{StringVal: "4f2a"}},
+			},
+			ExpectedResults: map[string]interface{}{"digest": "sha256:0123", "commit": "4f2a"},
+			ExpectedTyped: []interface{}{
+				map[string]interface{}{"name": "digest", "type": "string", "string_value": "sha256:0123"},
+				map[string]interface{}{"name": "commit", "type": "string", "string_value": "4f2a"},
+			},
+		},
+		{
+			Results: []tektonapiv1.TaskRunResult{
+				{Name: "tags", Type: tektonapiv1.ResultsTypeArray, Value: *tektonapiv1.NewStructuredValues("latest", "v1.0.0")},
+			},
+			ExpectedResults: map[string]interface{}{"tags": `["latest","v1.0.0"]`},
+			ExpectedTyped: []interface{}{
+				map[string]interface{}{"name": "tags", "type": "array", "array_value": []interface{}{"latest", "v1.0.0"}},
+			},
+		},
+		{
+			Results: []tektonapiv1.TaskRunResult{
+				{Name: "image", Type: tektonapiv1.ResultsTypeObject, Value: *tektonapiv1.NewObject(map[string]string{"url": "registry.local/build", "digest": "sha256:0123"})},
+			},
+			ExpectedResults: map[string]interface{}{"image": `{"digest":"sha256:0123","url":"registry.local/build"}`},
+			ExpectedTyped: []interface{}{
+				map[string]interface{}{"name": "image", "type": "object", "object_value": map[string]interface{}{"url": "registry.local/build", "digest": "sha256:0123"}},
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			resourceData := schema.TestResourceDataRaw(t, TektonTaskRunFields(), map[string]interface{}{})
+			if err := SetTektonTaskRunResults(resourceData, tc.Results); err != nil {
+				t.Fatal(err)
+			}
+			assertTektonRunResults(t, resourceData, tc.ExpectedResults, tc.ExpectedTyped)
+		})
+	}
+}
+
+func TestSetTektonPipelineRunResults(t *testing.T) {
+	results := []tektonapiv1.PipelineRunResult{
+		{Name: "digest", Value: *tektonapiv1.NewStructuredValues("sha256:0123")},
+		{Name: "tags", Value: *tektonapiv1.NewStructuredValues("latest", "v1.0.0")},
+		{Name: "image", Value: *tektonapiv1.NewObject(map[string]string{"url": "registry.local/build"})},
+	}
+
+	resourceData := schema.TestResourceDataRaw(t, TektonTaskRunFields(), map[string]interface{}{})
+	if err := SetTektonPipelineRunResults(resourceData, results); err != nil {
+		t.Fatal(err)
+	}
+	assertTektonRunResults(t, resourceData,
+		map[string]interface{}{"digest": "sha256:0123", "tags": `["latest","v1.0.0"]`, "image": `{"url":"registry.local/build"}`},
+		[]interface{}{
+			map[string]interface{}{"name": "digest", "type": "string", "string_value": "sha256:0123"},
+			map[string]interface{}{"name": "tags", "type": "array", "array_value": []interface{}{"latest", "v1.0.0"}},
+			map[string]interface{}{"name": "image", "type": "object", "object_value": map[string]interface{}{"url": "registry.local/build"}},
+		})
+}
+
+// assertTektonRunResults checks the results and typed_results attributes of resourceData, the
+// typed results being compared on the attributes expected only.
+func assertTektonRunResults(t *testing.T, resourceData *schema.ResourceData, expectedResults map[string]interface{}, expectedTyped []interface{}) {
+	t.Helper()
+
+	if results := resourceData.Get("results").(map[string]interface{}); !reflect.DeepEqual(results, expectedResults) {
+		t.Fatalf("Expected the results %v, given: %v", expectedResults, results)
+	}
+
+	typed := resourceData.Get("typed_results").([]interface{})
+	if len(typed) != len(expectedTyped) {
+		t.Fatalf("Expected %d typed results, given: %v", len(expectedTyped), typed)
+	}
+	for i, expected := range expectedTyped {
+		given := typed[i].(map[string]interface{})
+		for k, v := range expected.(map[string]interface{}) {
+			if !reflect.DeepEqual(given[k], v) {
+				t.Fatalf("Expected the typed result %d to have %s %v, given: %v", i, k, v, given)
+			}
+		}
+	}
+}
@@ -2,92 +2,104 @@ package task_run
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/rh01/terraform-provider-tekton/tekton/schema/k8s"
+	"github.com/rh01/terraform-provider-tekton/tekton/schema/pipeline"
+	"github.com/rh01/terraform-provider-tekton/tekton/schema/task"
+	"github.com/rh01/terraform-provider-tekton/tekton/utils"
 	tektonapiv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func tektonTaskRunSpecFields() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
-		"params": {
+		"task_ref": {
 			Type:        schema.TypeList,
-			Description: "Params is a list of input parameters required to run the task. Params must be supplied as inputs in TaskRunRuns unless they declare a default value.",
+			Description: "TaskRef is a reference to the Task to run. Exactly one of task_ref or task_spec must be set.",
 			Optional:    true,
-			// MaxItems:    1,
+			MaxItems:    1,
 			Elem: &schema.Resource{
-				Schema: tektonParamSpecFields(),
+				Schema: pipeline.TektonTaskRefFields(),
 			},
 		},
-		"display_name": {
-			Type:        schema.TypeString,
-			Description: "DisplayName is a user-facing name of the task that may be used to populate a UI.",
-			Optional:    true,
-		},
-		"description": {
-			Type:        schema.TypeString,
-			Description: "Description is a user-facing description of the task that may be used to populate a UI.",
+		"task_spec": {
+			Type:        schema.TypeList,
+			Description: "TaskSpec is a specification of the Task to run, embedded in the TaskRun. Exactly one of task_ref or task_spec must be set.",
 			Optional:    true,
+			MaxItems:    1,
+			Elem: &schema.Resource{
+				Schema: task.TektonTaskSpecFields(),
+			},
 		},
-		"steps": {
+		"params": {
 			Type:        schema.TypeList,
-			Description: "Steps are the steps of the build; each step is run sequentially with the source mounted into /workspace.",
+			Description: "Params is a list of parameter names and values passed to the Task.",
 			Optional:    true,
 			Elem: &schema.Resource{
-				Schema: tektonStepFields(),
+				Schema: pipeline.TektonParamFields(),
 			},
 		},
-		// "volumes": {
-		// 	Type:        schema.TypeList,
-		// 	Description: "Volumes is a collection of volumes that are available to mount into the steps of the build.",
-		// 	Optional:    true,
-		// 	Elem: &schema.Resource{
-		// 		Schema: tektonVolumeFields(),
-		// 	},
-		// },
-		"step_template": {
+		"workspaces": {
 			Type:        schema.TypeList,
-			Description: "StepTemplate can be used as the basis for all step containers within the TaskRun, so that the steps inherit settings on the base container.",
+			Description: "Workspaces is a list of WorkspaceBindings from volumes to workspaces.",
 			Optional:    true,
-			MaxItems:    1,
 			Elem: &schema.Resource{
-				Schema: tektonStepTemplateFields(),
+				Schema: TektonWorkspaceBindingFields(),
 			},
 		},
-		"sidecars": {
+		"service_account_name": {
+			Type:        schema.TypeString,
+			Description: "ServiceAccountName is the name of the ServiceAccount to use to run the TaskRun's Pod. Defaults to the default-service-account of the Tekton installation.",
+			Optional:    true,
+			Computed:    true,
+		},
+		"retries": {
+			Type:         schema.TypeInt,
+			Description:  "Retries represents how many times this TaskRun should be retried in the event of task failure.",
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(0),
+		},
+		"timeout": {
+			Type:             schema.TypeString,
+			Description:      "Time after which the TaskRun times out. Defaults to the default-timeout-minutes of the Tekton installation. Refer Go's ParseDuration documentation for expected format: https://golang.org/pkg/time/#ParseDuration",
+			Optional:         true,
+			Computed:         true,
+			ValidateFunc:     utils.ValidateDuration,
+			DiffSuppressFunc: utils.SuppressEquivalentDuration,
+		},
+		"compute_resources": k8s.ResourceRequirementsSchema("ComputeResources are the compute resources to use for the TaskRun, shared by all of its steps."),
+		"step_specs": {
 			Type:        schema.TypeList,
-			Description: "Sidecars are run alongside the TaskRun's step containers. They begin before the steps start and end after the steps complete.",
+			Description: "StepSpecs overrides the compute resources of the Steps of the Task.",
 			Optional:    true,
 			Elem: &schema.Resource{
-				Schema: tektonSidecarFields(),
+				Schema: TektonTaskRunStepSpecFields(),
 			},
 		},
-		"workspaces": {
+		"sidecar_specs": {
 			Type:        schema.TypeList,
-			Description: "Workspaces are the volumes that this TaskRun requires.",
+			Description: "SidecarSpecs overrides the compute resources of the Sidecars of the Task.",
 			Optional:    true,
 			Elem: &schema.Resource{
-				Schema: tektonWorkspaceDeclarationFields(),
+				Schema: TektonTaskRunSidecarSpecFields(),
 			},
 		},
-		// "results": {
-		// 	Type:        schema.TypeList,
-		// 	Description: "Results are values that this TaskRun can output",
-		// 	Optional:    true,
-		// 	Elem: &schema.Resource{
-		// 		Schema: tektonTaskRunResultFields(),
-		// 	},
-		// },
+		"pod_template": k8s.PodTemplateSchema("TaskRun"),
 	}
 }
 
 func tektonTaskRunSpecSchema() *schema.Schema {
-	fields := tektonTaskRunSpecFields()
+	// A TaskRun is not re-run when its spec changes, a new one is created instead.
+	fields := utils.ForceNewFields(tektonTaskRunSpecFields())
 
 	return &schema.Schema{
 		Type:        schema.TypeList,
 		Description: fmt.Sprintf("TektonTaskRunSpec describes how the proper TektonTaskRun should look like."),
 		Required:    true,
+		ForceNew:    true,
 		MaxItems:    1,
 		Elem: &schema.Resource{
 			Schema: fields,
@@ -96,95 +108,83 @@ func tektonTaskRunSpecSchema() *schema.Schema {
 
 }
 
-func tektonParamSpecFields() map[string]*schema.Schema {
-	return map[string]*schema.Schema{
-		"name": {
-			Type:        schema.TypeString,
-			Description: "Name declares the name by which a parameter is referenced.",
-			Required:    true,
-		},
-		"type": {
-			Type:         schema.TypeString,
-			Description:  "Type is the user-specified type of the parameter. The possible types are currently string, array and object, and string is the default.",
-			Optional:     true,
-			ValidateFunc: validation.StringInSlice([]string{"string", "array", "object"}, false),
-		},
-		"description": {
-			Type:        schema.TypeString,
-			Description: "Description is a user-facing description of the parameter that may be used to populate a UI.",
-			Optional:    true,
-		},
-		"properties": {
-			Type:        schema.TypeMap,
-			Description: "Properties is the JSON Schema properties to support key-value pairs parameter.",
-			Optional:    true,
-			Elem: &schema.Resource{
-				Schema: tektonPropertySpecFields(),
-			},
-		},
-		"default": {
-			Type:        schema.TypeList,
-			Description: "Default is the value a parameter takes if no input value is supplied. If default is set, a TaskRun may be executed without a supplied value for the parameter.",
-			Optional:    true,
-			MaxItems:    1,
-			Elem: &schema.Resource{
-				Schema: tektonParamValueFields(),
-			},
-		},
-	}
-}
-
-func tektonPropertySpecFields() map[string]*schema.Schema {
-	return map[string]*schema.Schema{
-		"type": {
-			Type:         schema.TypeString,
-			Description:  "Type is the user-specified type of the parameter. The possible types are currently string, array and object, and string is the default.",
-			Optional:     true,
-			ValidateFunc: validation.StringInSlice([]string{"string", "array", "object"}, false),
-		},
-	}
-}
+func expandTektonTaskRunSpec(taskRun []interface{}) (tektonapiv1.TaskRunSpec, error) {
+	result := tektonapiv1.TaskRunSpec{}
 
-func tektonParamValueFields() map[string]*schema.Schema {
-	return map[string]*schema.Schema{
-		"type": {
-			Type:         schema.TypeString,
-			Description:  "Type is the user-specified type of the parameter. The possible types are currently string, array and object, and string is the default.",
-			Optional:     true,
-			ValidateFunc: validation.StringInSlice([]string{"string", "array", "object"}, false),
-		},
-		"string_val": {
-			Type:        schema.TypeString,
-			Description: "StringVal is a string value.",
-			Optional:    true,
-		},
-		"array_val": {
-			Type:        schema.TypeList,
-			Description: "ArrayVal is an array of strings.",
-			Optional:    true,
-			Elem: &schema.Schema{
-				Type: schema.TypeString,
-			},
-		},
-		"object_val": {
-			Type:        schema.TypeMap,
-			Description: "ObjectVal is a map of strings to strings.",
-			Optional:    true,
-			Elem: &schema.Schema{
-				Type: schema.TypeString,
-			},
-		},
+	if len(taskRun) == 0 || taskRun[0] == nil {
+		return result, nil
 	}
-}
 
-func expandTektonTaskRunSpec(task []interface{}) (tektonapiv1.TaskRunSpec, error) {
-	result := tektonapiv1.TaskRunSpec{}
+	in := taskRun[0].(map[string]interface{})
 
-	if len(task) == 0 || task[0] == nil {
-		return result, nil
+	taskRef := in["task_ref"].([]interface{})
+	taskSpec := in["task_spec"].([]interface{})
+	if len(taskRef) > 0 && len(taskSpec) > 0 {
+		return result, fmt.Errorf("only one of task_ref or task_spec can be set")
+	}
+	if len(taskRef) == 0 && len(taskSpec) == 0 {
+		return result, fmt.Errorf("one of task_ref or task_spec must be set")
+	}
+	result.TaskRef = pipeline.ExpandTektonTaskRef(taskRef)
+	if len(taskSpec) > 0 {
+		spec, err := task.ExpandTektonTaskSpec(taskSpec)
+		if err != nil {
+			return result, err
+		}
+		result.TaskSpec = &spec
 	}
 
-	_ = task[0].(map[string]interface{})
+	if v, ok := in["params"].([]interface{}); ok {
+		result.Params = pipeline.ExpandTektonParams(v)
+	}
+	if v, ok := in["workspaces"].([]interface{}); ok {
+		workspaces, err := ExpandTektonWorkspaceBindings(v)
+		if err != nil {
+			return result, err
+		}
+		result.Workspaces = workspaces
+	}
+	if v, ok := in["service_account_name"].(string); ok {
+		result.ServiceAccountName = v
+	}
+	if v, ok := in["retries"].(int); ok {
+		result.Retries = v
+	}
+	if v, ok := in["timeout"].(string); ok && v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return result, err
+		}
+		result.Timeout = &metav1.Duration{Duration: d}
+	}
+	if v, ok := in["compute_resources"].([]interface{}); ok {
+		resources, err := k8s.ExpandResourceRequirements(v)
+		if err != nil {
+			return result, err
+		}
+		result.ComputeResources = resources
+	}
+	if v, ok := in["step_specs"].([]interface{}); ok {
+		stepSpecs, err := ExpandTektonTaskRunStepSpecs(v)
+		if err != nil {
+			return result, err
+		}
+		result.StepSpecs = stepSpecs
+	}
+	if v, ok := in["sidecar_specs"].([]interface{}); ok {
+		sidecarSpecs, err := ExpandTektonTaskRunSidecarSpecs(v)
+		if err != nil {
+			return result, err
+		}
+		result.SidecarSpecs = sidecarSpecs
+	}
+	if v, ok := in["pod_template"].([]interface{}); ok {
+		podTemplate, err := k8s.ExpandPodTemplate(v)
+		if err != nil {
+			return result, err
+		}
+		result.PodTemplate = podTemplate
+	}
 
 	return result, nil
 }
@@ -192,5 +192,21 @@ func expandTektonTaskRunSpec(task []interface{}) (tektonapiv1.TaskRunSpec, error
 func flattenTektonTaskRunSpec(in tektonapiv1.TaskRunSpec) []interface{} {
 	att := make(map[string]interface{})
 
+	att["task_ref"] = pipeline.FlattenTektonTaskRef(in.TaskRef)
+	if in.TaskSpec != nil {
+		att["task_spec"] = task.FlattenTektonTaskSpec(*in.TaskSpec)
+	}
+	att["params"] = pipeline.FlattenTektonParams(in.Params)
+	att["workspaces"] = FlattenTektonWorkspaceBindings(in.Workspaces)
+	att["service_account_name"] = in.ServiceAccountName
+	att["retries"] = in.Retries
+	if in.Timeout != nil {
+		att["timeout"] = in.Timeout.Duration.String()
+	}
+	att["compute_resources"] = k8s.FlattenResourceRequirements(in.ComputeResources)
+	att["step_specs"] = FlattenTektonTaskRunStepSpecs(in.StepSpecs)
+	att["sidecar_specs"] = FlattenTektonTaskRunSidecarSpecs(in.SidecarSpecs)
+	att["pod_template"] = k8s.FlattenPodTemplate(in.PodTemplate)
+
 	return []interface{}{att}
 }
@@ -0,0 +1,142 @@
+package task_run
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestTaskRunSpecRoundTrip(t *testing.T) {
+	testCases := []struct {
+		Spec map[string]interface{}
+	}{
+		{
+			Spec: map[string]interface{}{
+				"task_ref": []interface{}{map[string]interface{}{"name": "build"}},
+			},
+		},
+		{
+			Spec: map[string]interface{}{
+				"task_ref":             []interface{}{map[string]interface{}{"name": "build", "kind": "Task"}},
+				"params":               []interface{}{map[string]interface{}{"name": "revision", "value": []interface{}{map[string]interface{}{"string_val": "main"}}}},
+				"workspaces":           []interface{}{map[string]interface{}{"name": "source", "empty_dir": []interface{}{map[string]interface{}{}}}},
+				"service_account_name": "builder",
+				"retries":              2,
+				"timeout":              "30m0s",
+				"compute_resources":    []interface{}{map[string]interface{}{"limits": map[string]interface{}{"cpu": "2"}}},
+				"step_specs":           []interface{}{map[string]interface{}{"name": "build", "compute_resources": []interface{}{map[string]interface{}{"requests": map[string]interface{}{"memory": "1Gi"}}}}},
+				"pod_template": []interface{}{map[string]interface{}{
+					"node_selector": map[string]interface{}{"kubernetes.io/arch": "amd64"},
+					"toleration":    []interface{}{map[string]interface{}{"key": "ci", "operator": "Exists", "effect": "NoSchedule"}},
+				}},
+			},
+		},
+		{
+			Spec: map[string]interface{}{
+				"task_spec": []interface{}{map[string]interface{}{
+					"steps": []interface{}{map[string]interface{}{"name": "build", "image": "golang", "script": "go build ./..."}},
+				}},
+				"sidecar_specs": []interface{}{map[string]interface{}{"name": "registry", "compute_resources": []interface{}{map[string]interface{}{"limits": map[string]interface{}{"memory": "256Mi"}}}}},
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			raw := map[string]interface{}{
+				"metadata": []interface{}{map[string]interface{}{"name": "build-1", "namespace": "default"}},
+				"spec":     []interface{}{tc.Spec},
+			}
+			expanded, err := FromResourceData(schema.TestResourceDataRaw(t, TektonTaskRunFields(), raw))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resourceData := schema.TestResourceDataRaw(t, TektonTaskRunFields(), map[string]interface{}{})
+			if err := ToResourceData(*expanded, resourceData); err != nil {
+				t.Fatal(err)
+			}
+			given, err := FromResourceData(resourceData)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !equality.Semantic.DeepEqual(expanded.Spec, given.Spec) {
+				expected, _ := json.Marshal(expanded.Spec)
+				actual, _ := json.Marshal(given.Spec)
+				t.Fatalf("TaskRun specs don't match.\nExpected: %s\nGiven:    %s\n", expected, actual)
+			}
+		})
+	}
+}
+
+func TestTaskRunSpecDefaultsDiff(t *testing.T) {
+	testCases := []struct {
+		Spec map[string]interface{}
+	}{
+		{
+			Spec: map[string]interface{}{
+				"task_ref": []interface{}{map[string]interface{}{"name": "build"}},
+			},
+		},
+		{
+			Spec: map[string]interface{}{
+				"task_ref":     []interface{}{map[string]interface{}{"name": "build"}},
+				"timeout":      "1h",
+				"pod_template": []interface{}{map[string]interface{}{"node_selector": map[string]interface{}{"kubernetes.io/arch": "amd64"}}},
+			},
+		},
+	}
+
+	fields := TektonTaskRunFields()
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			raw := map[string]interface{}{
+				"metadata": []interface{}{map[string]interface{}{"name": "build-1", "namespace": "default"}},
+				"spec":     []interface{}{tc.Spec},
+			}
+			taskRun, err := FromResourceData(schema.TestResourceDataRaw(t, fields, raw))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// The Tekton webhook sets the defaults of the installation when the TaskRun is created.
+			if taskRun.Spec.ServiceAccountName == "" {
+				taskRun.Spec.ServiceAccountName = "default"
+			}
+			if taskRun.Spec.Timeout == nil {
+				taskRun.Spec.Timeout = &metav1.Duration{Duration: time.Hour}
+			}
+
+			resourceData := schema.TestResourceDataRaw(t, fields, map[string]interface{}{})
+			if err := ToResourceData(*taskRun, resourceData); err != nil {
+				t.Fatal(err)
+			}
+			resourceData.SetId("default/build-1")
+
+			diff, err := schema.InternalMap(fields).Diff(context.Background(), resourceData.State(), terraform.NewResourceConfigRaw(raw), nil, nil, true)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff == nil {
+				return
+			}
+			for k, v := range diff.Attributes {
+				if strings.HasPrefix(k, "spec.") {
+					t.Fatalf("Expected no change of the spec, given: %s: %#v", k, v)
+				}
+			}
+			if diff.RequiresNew() {
+				t.Fatalf("Expected the TaskRun not to be replaced, given: %#v", diff.Attributes)
+			}
+		})
+	}
+}
@@ -0,0 +1,205 @@
+package task_run
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	tektonapiv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	containerStateWaiting    = "waiting"
+	containerStateRunning    = "running"
+	containerStateTerminated = "terminated"
+)
+
+func tektonTaskRunStatusSchema() *schema.Schema {
+	fields := tektonTaskRunStatusFields()
+	fields["retries_status"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "RetriesStatus contains the status of the previous attempts of the TaskRun, in case of a retry.",
+		Computed:    true,
+		Elem: &schema.Resource{
+			Schema: tektonTaskRunStatusFields(),
+		},
+	}
+
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "Status is the current status of the TaskRun, refreshed on every read.",
+		Computed:    true,
+		Elem: &schema.Resource{
+			Schema: fields,
+		},
+	}
+}
+
+func tektonTaskRunStatusFields() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"pod_name": {
+			Type:        schema.TypeString,
+			Description: "PodName is the name of the pod responsible for executing this task's steps.",
+			Computed:    true,
+		},
+		"start_time": {
+			Type:        schema.TypeString,
+			Description: "StartTime is the time the TaskRun is actually started.",
+			Computed:    true,
+		},
+		"completion_time": {
+			Type:        schema.TypeString,
+			Description: "CompletionTime is the time the TaskRun completed.",
+			Computed:    true,
+		},
+		"conditions": {
+			Type:        schema.TypeList,
+			Description: "Conditions are the latest available observations of the TaskRun's state.",
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: TektonRunConditionFields(),
+			},
+		},
+		"succeeded": {
+			Type:        schema.TypeBool,
+			Description: "Succeeded is true when the Succeeded condition of the TaskRun is True.",
+			Computed:    true,
+		},
+		"steps": {
+			Type:        schema.TypeList,
+			Description: "Steps describes the state of each step container.",
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: tektonContainerStateFields(),
+			},
+		},
+		"sidecars": {
+			Type:        schema.TypeList,
+			Description: "Sidecars describes the state of each sidecar container.",
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: tektonContainerStateFields(),
+			},
+		},
+		"span_context": {
+			Type:        schema.TypeMap,
+			Description: "SpanContext contains tracing span context fields",
+			Computed:    true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+	}
+}
+
+func tektonContainerStateFields() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:        schema.TypeString,
+			Description: "Name of the step or sidecar.",
+			Computed:    true,
+		},
+		"container": {
+			Type:        schema.TypeString,
+			Description: "Container is the name of the container running the step or sidecar.",
+			Computed:    true,
+		},
+		"image_id": {
+			Type:        schema.TypeString,
+			Description: "ImageID of the container.",
+			Computed:    true,
+		},
+		"state": {
+			Type:        schema.TypeString,
+			Description: "State of the container: waiting, running or terminated.",
+			Computed:    true,
+		},
+		"reason": {
+			Type:        schema.TypeString,
+			Description: "Reason the container is waiting or terminated, such as Completed or Error.",
+			Computed:    true,
+		},
+		"message": {
+			Type:        schema.TypeString,
+			Description: "Message regarding the waiting or terminated state of the container.",
+			Computed:    true,
+		},
+		"exit_code": {
+			Type:        schema.TypeInt,
+			Description: "ExitCode of the terminated container.",
+			Computed:    true,
+		},
+		"started_at": {
+			Type:        schema.TypeString,
+			Description: "StartedAt is the time the container started.",
+			Computed:    true,
+		},
+		"finished_at": {
+			Type:        schema.TypeString,
+			Description: "FinishedAt is the time the container terminated.",
+			Computed:    true,
+		},
+	}
+}
+
+func flattenTektonTaskRunStatus(in tektonapiv1.TaskRunStatus) []interface{} {
+	att := flattenTektonTaskRunStatusFields(in)
+
+	retries := make([]interface{}, 0, len(in.RetriesStatus))
+	for _, v := range in.RetriesStatus {
+		retries = append(retries, flattenTektonTaskRunStatusFields(v))
+	}
+	att["retries_status"] = retries
+
+	return []interface{}{att}
+}
+
+func flattenTektonTaskRunStatusFields(in tektonapiv1.TaskRunStatus) map[string]interface{} {
+	att := make(map[string]interface{})
+
+	att["pod_name"] = in.PodName
+	att["start_time"] = FlattenTektonRunTime(in.StartTime)
+	att["completion_time"] = FlattenTektonRunTime(in.CompletionTime)
+	att["conditions"] = FlattenTektonRunConditions(in.Conditions)
+	att["succeeded"] = FlattenTektonRunSucceeded(in.Status)
+
+	steps := make([]interface{}, 0, len(in.Steps))
+	for _, v := range in.Steps {
+		steps = append(steps, flattenTektonContainerState(v.Name, v.Container, v.ImageID, v.ContainerState))
+	}
+	att["steps"] = steps
+
+	sidecars := make([]interface{}, 0, len(in.Sidecars))
+	for _, v := range in.Sidecars {
+		sidecars = append(sidecars, flattenTektonContainerState(v.Name, v.Container, v.ImageID, v.ContainerState))
+	}
+	att["sidecars"] = sidecars
+
+	att["span_context"] = in.SpanContext
+
+	return att
+}
+
+func flattenTektonContainerState(name, container, imageID string, in v1.ContainerState) map[string]interface{} {
+	att := make(map[string]interface{})
+	att["name"] = name
+	att["container"] = container
+	att["image_id"] = imageID
+
+	switch {
+	case in.Terminated != nil:
+		att["state"] = containerStateTerminated
+		att["reason"] = in.Terminated.Reason
+		att["message"] = in.Terminated.Message
+		att["exit_code"] = int(in.Terminated.ExitCode)
+		att["started_at"] = FlattenTektonRunTime(&in.Terminated.StartedAt)
+		att["finished_at"] = FlattenTektonRunTime(&in.Terminated.FinishedAt)
+	case in.Running != nil:
+		att["state"] = containerStateRunning
+		att["started_at"] = FlattenTektonRunTime(&in.Running.StartedAt)
+	case in.Waiting != nil:
+		att["state"] = containerStateWaiting
+		att["reason"] = in.Waiting.Reason
+		att["message"] = in.Waiting.Message
+	}
+
+	return att
+}
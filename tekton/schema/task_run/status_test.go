@@ -0,0 +1,102 @@
+package task_run
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	tektonapiv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+func TestFlattenTektonTaskRunStatus(t *testing.T) {
+	start := metav1.NewTime(time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC))
+	end := metav1.NewTime(start.Add(90 * time.Second))
+
+	status := tektonapiv1.TaskRunStatus{
+		Status: duckv1.Status{Conditions: duckv1.Conditions{
+			{Type: apis.ConditionSucceeded, Status: corev1.ConditionFalse, Reason: "Failed", Message: "step test failed"},
+		}},
+		TaskRunStatusFields: tektonapiv1.TaskRunStatusFields{
+			PodName:        "build-1-pod",
+			StartTime:      &start,
+			CompletionTime: &end,
+			Steps: []tektonapiv1.StepState{
+				{Name: "build", Container: "step-build", ImageID: "golang@sha256:0123", ContainerState: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{ExitCode: 0, Reason: "Completed", StartedAt: start, FinishedAt: end},
+				}},
+				{Name: "test", Container: "step-test", ContainerState: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{ExitCode: 1, Reason: "Error", Message: "exit status 1", StartedAt: start, FinishedAt: end},
+				}},
+			},
+			Sidecars: []tektonapiv1.SidecarState{
+				{Name: "registry", Container: "sidecar-registry", ContainerState: corev1.ContainerState{
+					Running: &corev1.ContainerStateRunning{StartedAt: start},
+				}},
+			},
+			RetriesStatus: []tektonapiv1.TaskRunStatus{{
+				Status: duckv1.Status{Conditions: duckv1.Conditions{
+					{Type: apis.ConditionSucceeded, Status: corev1.ConditionFalse, Reason: "Failed"},
+				}},
+				TaskRunStatusFields: tektonapiv1.TaskRunStatusFields{
+					PodName: "build-1-pod-retry1",
+					Steps: []tektonapiv1.StepState{
+						{Name: "build", Container: "step-build", ContainerState: corev1.ContainerState{
+							Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff", Message: "Back-off pulling image"},
+						}},
+					},
+				},
+			}},
+		},
+	}
+
+	expected := []interface{}{map[string]interface{}{
+		"pod_name":        "build-1-pod",
+		"start_time":      "2023-05-01T10:00:00Z",
+		"completion_time": "2023-05-01T10:01:30Z",
+		"conditions": []interface{}{
+			map[string]interface{}{"type": "Succeeded", "status": "False", "reason": "Failed", "message": "step test failed"},
+		},
+		"succeeded": false,
+		"steps": []interface{}{
+			map[string]interface{}{"name": "build", "container": "step-build", "image_id": "golang@sha256:0123", "state": "terminated", "reason": "Completed", "message": "", "exit_code": 0, "started_at": "2023-05-01T10:00:00Z", "finished_at": "2023-05-01T10:01:30Z"},
+			map[string]interface{}{"name": "test", "container": "step-test", "image_id": "", "state": "terminated", "reason": "Error", "message": "exit status 1", "exit_code": 1, "started_at": "2023-05-01T10:00:00Z", "finished_at": "2023-05-01T10:01:30Z"},
+		},
+		"sidecars": []interface{}{
+			map[string]interface{}{"name": "registry", "container": "sidecar-registry", "image_id": "", "state": "running", "started_at": "2023-05-01T10:00:00Z"},
+		},
+		"span_context": map[string]string(nil),
+		"retries_status": []interface{}{map[string]interface{}{
+			"pod_name":        "build-1-pod-retry1",
+			"start_time":      "",
+			"completion_time": "",
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "Succeeded", "status": "False", "reason": "Failed", "message": ""},
+			},
+			"succeeded": false,
+			"steps": []interface{}{
+				map[string]interface{}{"name": "build", "container": "step-build", "image_id": "", "state": "waiting", "reason": "ImagePullBackOff", "message": "Back-off pulling image"},
+			},
+			"sidecars":     []interface{}{},
+			"span_context": map[string]string(nil),
+		}},
+	}}
+
+	given := flattenTektonTaskRunStatus(status)
+	if !reflect.DeepEqual(given, expected) {
+		t.Fatalf("Statuses don't match.\nExpected: %#v\nGiven:    %#v\n", expected, given)
+	}
+
+	// The flattened status matches the schema of the attribute.
+	resourceData := schema.TestResourceDataRaw(t, TektonTaskRunFields(), map[string]interface{}{})
+	if err := resourceData.Set("status", given); err != nil {
+		t.Fatal(err)
+	}
+	if v := resourceData.Get("status.0.steps.1.exit_code").(int); v != 1 {
+		t.Fatalf("Expected the exit code 1 of the step test, given: %d", v)
+	}
+}
@@ -0,0 +1,115 @@
+package task_run
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/rh01/terraform-provider-tekton/tekton/schema/k8s"
+	tektonapiv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	v1 "k8s.io/api/core/v1"
+)
+
+func TektonTaskRunStepSpecFields() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:        schema.TypeString,
+			Description: "The name of the Step to override.",
+			Required:    true,
+		},
+		"compute_resources": k8s.ResourceRequirementsSchema("The resource requirements to apply to the Step."),
+	}
+}
+
+func TektonTaskRunSidecarSpecFields() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:        schema.TypeString,
+			Description: "The name of the Sidecar to override.",
+			Required:    true,
+		},
+		"compute_resources": k8s.ResourceRequirementsSchema("The resource requirements to apply to the Sidecar."),
+	}
+}
+
+func ExpandTektonTaskRunStepSpecs(in []interface{}) ([]tektonapiv1.TaskRunStepSpec, error) {
+	if len(in) == 0 {
+		return nil, nil
+	}
+
+	result := make([]tektonapiv1.TaskRunStepSpec, 0, len(in))
+	for _, s := range in {
+		m, ok := s.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		resources, err := expandComputeResources(m["compute_resources"].([]interface{}))
+		if err != nil {
+			return result, err
+		}
+		result = append(result, tektonapiv1.TaskRunStepSpec{
+			Name:             m["name"].(string),
+			ComputeResources: resources,
+		})
+	}
+
+	return result, nil
+}
+
+func ExpandTektonTaskRunSidecarSpecs(in []interface{}) ([]tektonapiv1.TaskRunSidecarSpec, error) {
+	if len(in) == 0 {
+		return nil, nil
+	}
+
+	result := make([]tektonapiv1.TaskRunSidecarSpec, 0, len(in))
+	for _, s := range in {
+		m, ok := s.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		resources, err := expandComputeResources(m["compute_resources"].([]interface{}))
+		if err != nil {
+			return result, err
+		}
+		result = append(result, tektonapiv1.TaskRunSidecarSpec{
+			Name:             m["name"].(string),
+			ComputeResources: resources,
+		})
+	}
+
+	return result, nil
+}
+
+func expandComputeResources(in []interface{}) (v1.ResourceRequirements, error) {
+	resources, err := k8s.ExpandResourceRequirements(in)
+	if err != nil || resources == nil {
+		return v1.ResourceRequirements{}, err
+	}
+
+	return *resources, nil
+}
+
+func FlattenTektonTaskRunStepSpecs(in []tektonapiv1.TaskRunStepSpec) []interface{} {
+	result := make([]interface{}, 0, len(in))
+
+	for _, v := range in {
+		att := make(map[string]interface{})
+		att["name"] = v.Name
+		att["compute_resources"] = k8s.FlattenResourceRequirements(&v.ComputeResources)
+
+		result = append(result, att)
+	}
+
+	return result
+}
+
+func FlattenTektonTaskRunSidecarSpecs(in []tektonapiv1.TaskRunSidecarSpec) []interface{} {
+	result := make([]interface{}, 0, len(in))
+
+	for _, v := range in {
+		att := make(map[string]interface{})
+		att["name"] = v.Name
+		att["compute_resources"] = k8s.FlattenResourceRequirements(&v.ComputeResources)
+
+		result = append(result, att)
+	}
+
+	return result
+}
@@ -8,10 +8,21 @@ import (
 )
 
 func TektonTaskRunFields() map[string]*schema.Schema {
-	return map[string]*schema.Schema{
-		"metadata": k8s.NamespacedMetadataSchema("TaskRun", false),
-		"spec":     tektonTaskRunSpecSchema(),
+	fields := map[string]*schema.Schema{
+		"metadata":    k8s.NamespacedMetadataSchema("TaskRun", true),
+		"api_version": k8s.APIVersionSchema("TaskRun"),
+		"spec":        tektonTaskRunSpecSchema(),
+		"status":      tektonTaskRunStatusSchema(),
 	}
+	fields["triggers"] = TektonRunTriggersSchema()
+	for k, v := range TektonRunWaitFields() {
+		fields[k] = v
+	}
+	for k, v := range TektonRunResultFields() {
+		fields[k] = v
+	}
+
+	return fields
 }
 
 func ExpandTektonTaskRun(tasks []interface{}) (*tektonapiv1.TaskRun, error) {
@@ -42,6 +53,7 @@ func FlattenTektonTaskRun(in tektonapiv1.TaskRun) []interface{} {
 
 	att["metadata"] = k8s.FlattenMetadata(in.ObjectMeta)
 	att["spec"] = flattenTektonTaskRunSpec(in.Spec)
+	att["status"] = flattenTektonTaskRunStatus(in.Status)
 
 	return []interface{}{att}
 }
@@ -67,6 +79,13 @@ func ToResourceData(vm tektonapiv1.TaskRun, resourceData *schema.ResourceData) e
 		return err
 	}
 
+	if err := SetTektonTaskRunResults(resourceData, vm.Status.Results); err != nil {
+		return err
+	}
+	if err := resourceData.Set("status", flattenTektonTaskRunStatus(vm.Status)); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -0,0 +1,19 @@
+package task_run
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// TektonRunTriggersSchema is an arbitrary map of values that, when changed, creates a new TaskRun or
+// PipelineRun, in the same way as the triggers of a null_resource.
+func TektonRunTriggersSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeMap,
+		Description: "A map of arbitrary strings that, when changed, will force the run to be replaced by a new one.",
+		Optional:    true,
+		ForceNew:    true,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+}
@@ -0,0 +1,48 @@
+package task_run
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	// WaitForNone returns as soon as the run has been created.
+	WaitForNone = "none"
+	// WaitForStarted waits until the run has been picked up by the Tekton controller.
+	WaitForStarted = "started"
+	// WaitForCompleted waits until the run has succeeded or failed.
+	WaitForCompleted = "completed"
+
+	// OnFailureFail fails the apply when the run fails.
+	OnFailureFail = "fail"
+	// OnFailureWarn keeps a failed run in state and only warns about the failure.
+	OnFailureWarn = "warn"
+)
+
+// TektonRunWaitFields are the attributes controlling how long Terraform waits for a TaskRun or
+// PipelineRun after creating it, they are not part of the Tekton object.
+func TektonRunWaitFields() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"wait_for": {
+			Type:         schema.TypeString,
+			Description:  "WaitFor sets how long to wait after creating the run: none, started (the Succeeded condition is set) or completed (the Succeeded condition is True or False). Defaults to completed.",
+			Optional:     true,
+			Default:      WaitForCompleted,
+			ValidateFunc: validation.StringInSlice([]string{WaitForNone, WaitForStarted, WaitForCompleted}, false),
+		},
+		"on_failure": {
+			Type:         schema.TypeString,
+			Description:  "OnFailure sets what happens when the run fails while waiting for it: fail returns an error and taints the resource, warn keeps the resource in state. Defaults to fail.",
+			Optional:     true,
+			Default:      OnFailureFail,
+			ValidateFunc: validation.StringInSlice([]string{OnFailureFail, OnFailureWarn}, false),
+		},
+		"failure_log_lines": {
+			Type:         schema.TypeInt,
+			Description:  "FailureLogLines is the number of lines of logs of each failed step reported when the run fails, 0 disables them. Defaults to 50.",
+			Optional:     true,
+			Default:      50,
+			ValidateFunc: validation.IntAtLeast(0),
+		},
+	}
+}
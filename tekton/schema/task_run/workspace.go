@@ -1,33 +1,142 @@
 package task_run
 
-import "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/rh01/terraform-provider-tekton/tekton/schema/k8s"
+	tektonapiv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+)
+
+// workspaceBindingSources are the volume sources a workspace can be bound to, exactly one of them must be set.
+var workspaceBindingSources = []string{
+	"persistent_volume_claim",
+	"volume_claim_template",
+	"empty_dir",
+	"config_map",
+	"secret",
+	"projected",
+	"csi",
+}
+
+// TektonWorkspaceBindingFields describes how a workspace declared by a Task or Pipeline is
+// backed by a volume when it is run.
+func TektonWorkspaceBindingFields() map[string]*schema.Schema {
+	volume := k8s.VolumeSchema().Schema
 
-func tektonWorkspaceDeclarationFields() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
 		"name": {
 			Type:        schema.TypeString,
-			Description: "Name of the workspace",
+			Description: "Name is the name of the workspace populated by the volume.",
 			Required:    true,
 		},
-		"description": {
+		"sub_path": {
 			Type:        schema.TypeString,
-			Description: "Description is an optional human readable description of this volume.",
-			Optional:    true,
-		},
-		"mount_path": {
-			Type:        schema.TypeString,
-			Description: "MountPath overrides the directory that the volume will be made available at",
-			Optional:    true,
-		},
-		"read_only": {
-			Type:        schema.TypeBool,
-			Description: "ReadOnly dictates whether a mounted volume is writable.",
-			Optional:    true,
-		},
-		"optional": {
-			Type:        schema.TypeBool,
-			Description: "Optional marks a Workspace as not being required in TaskRunRuns. By default  this field is false and so declared workspaces are required.",
+			Description: "SubPath is optionally a directory on the volume which should be used for this binding (i.e. the volume will be mounted at this sub directory).",
 			Optional:    true,
 		},
+		"persistent_volume_claim": volume["persistent_volume_claim"],
+		"volume_claim_template":   k8s.PersistentVolumeClaimTemplateSchema("VolumeClaimTemplate is a template for a claim that will be created in the same namespace. The PipelineRun controller is responsible for creating a unique claim for each instance of PipelineRun."),
+		"empty_dir":               volume["empty_dir"],
+		"config_map":              volume["config_map"],
+		"secret":                  volume["secret"],
+		"projected":               volume["projected"],
+		"csi":                     volume["csi"],
+	}
+}
+
+func ExpandTektonWorkspaceBindings(in []interface{}) ([]tektonapiv1.WorkspaceBinding, error) {
+	if len(in) == 0 {
+		return nil, nil
+	}
+
+	result := make([]tektonapiv1.WorkspaceBinding, 0, len(in))
+	for _, w := range in {
+		m, ok := w.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		binding, err := expandTektonWorkspaceBinding(m)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, binding)
 	}
+
+	return result, nil
+}
+
+func expandTektonWorkspaceBinding(in map[string]interface{}) (tektonapiv1.WorkspaceBinding, error) {
+	result := tektonapiv1.WorkspaceBinding{
+		Name:    in["name"].(string),
+		SubPath: in["sub_path"].(string),
+	}
+
+	var sources []string
+	for _, k := range workspaceBindingSources {
+		if v, ok := in[k].([]interface{}); ok && len(v) > 0 {
+			sources = append(sources, k)
+		}
+	}
+	if len(sources) != 1 {
+		return result, fmt.Errorf("workspace %q: exactly one of %v must be set, got %v", result.Name, workspaceBindingSources, sources)
+	}
+
+	var err error
+	switch v := in[sources[0]].([]interface{}); sources[0] {
+	case "persistent_volume_claim":
+		result.PersistentVolumeClaim = k8s.ExpandPersistentVolumeClaimVolumeSource(v)
+	case "volume_claim_template":
+		result.VolumeClaimTemplate, err = k8s.ExpandPersistentVolumeClaimTemplate(v)
+	case "empty_dir":
+		result.EmptyDir, err = k8s.ExpandEmptyDirVolumeSource(v)
+	case "config_map":
+		result.ConfigMap, err = k8s.ExpandConfigMapVolumeSource(v)
+	case "secret":
+		result.Secret, err = k8s.ExpandSecretVolumeSource(v)
+	case "projected":
+		result.Projected, err = k8s.ExpandProjectedVolumeSource(v)
+	case "csi":
+		result.CSI = k8s.ExpandCSIVolumeSource(v)
+	}
+	if err != nil {
+		return result, fmt.Errorf("workspace %q: %s", result.Name, err)
+	}
+
+	return result, nil
+}
+
+func FlattenTektonWorkspaceBindings(in []tektonapiv1.WorkspaceBinding) []interface{} {
+	result := make([]interface{}, 0, len(in))
+
+	for _, v := range in {
+		att := make(map[string]interface{})
+		att["name"] = v.Name
+		att["sub_path"] = v.SubPath
+		if v.PersistentVolumeClaim != nil {
+			att["persistent_volume_claim"] = k8s.FlattenPersistentVolumeClaimVolumeSource(v.PersistentVolumeClaim)
+		}
+		if v.VolumeClaimTemplate != nil {
+			att["volume_claim_template"] = k8s.FlattenPersistentVolumeClaimTemplate(v.VolumeClaimTemplate)
+		}
+		if v.EmptyDir != nil {
+			att["empty_dir"] = k8s.FlattenEmptyDirVolumeSource(v.EmptyDir)
+		}
+		if v.ConfigMap != nil {
+			att["config_map"] = k8s.FlattenConfigMapVolumeSource(v.ConfigMap)
+		}
+		if v.Secret != nil {
+			att["secret"] = k8s.FlattenSecretVolumeSource(v.Secret)
+		}
+		if v.Projected != nil {
+			att["projected"] = k8s.FlattenProjectedVolumeSource(v.Projected)
+		}
+		if v.CSI != nil {
+			att["csi"] = k8s.FlattenCSIVolumeSource(v.CSI)
+		}
+
+		result = append(result, att)
+	}
+
+	return result
 }
@@ -0,0 +1,21 @@
+package utils
+
+import (
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// SuppressEquivalentDuration ignores the difference between two spellings of the same
+// duration, e.g. "1h" in the configuration and "1h0m0s" returned by the API server.
+func SuppressEquivalentDuration(k, old, new string, d *schema.ResourceData) bool {
+	o, err := time.ParseDuration(old)
+	if err != nil {
+		return false
+	}
+	n, err := time.ParseDuration(new)
+	if err != nil {
+		return false
+	}
+	return o == n
+}
@@ -0,0 +1,115 @@
+package patch
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DiffObjects returns the operations patching the JSON representation of oldV into the JSON
+// representation of newV, both being rooted at pathPrefix. The operations are computed against the
+// state last read, so the client applies them leniently onto the live object: removing a value it
+// no longer has does nothing, and replacing a value it doesn't have yet adds it.
+func DiffObjects(pathPrefix string, oldV, newV interface{}) (PatchOperations, error) {
+	o, err := toJSONValue(oldV)
+	if err != nil {
+		return nil, err
+	}
+	n, err := toJSONValue(newV)
+	if err != nil {
+		return nil, err
+	}
+
+	return Diff(pathPrefix, o, n), nil
+}
+
+// Diff returns the operations patching oldV into newV, two values as decoded from JSON into an
+// interface{}. Maps are diffed key by key and lists index by index, so only the changed leaves
+// are replaced, and other values are replaced as a whole.
+func Diff(pathPrefix string, oldV, newV interface{}) PatchOperations {
+	ops := make([]PatchOperation, 0, 0)
+
+	return appendDiff(ops, strings.TrimRight(pathPrefix, "/"), oldV, newV)
+}
+
+func appendDiff(ops []PatchOperation, path string, oldV, newV interface{}) []PatchOperation {
+	switch o := oldV.(type) {
+	case map[string]interface{}:
+		if n, ok := newV.(map[string]interface{}); ok {
+			return appendMapDiff(ops, path, o, n)
+		}
+	case []interface{}:
+		if n, ok := newV.([]interface{}); ok {
+			return appendListDiff(ops, path, o, n)
+		}
+	}
+
+	switch {
+	case reflect.DeepEqual(oldV, newV):
+		return ops
+	case oldV == nil:
+		return append(ops, &AddOperation{Path: path, Value: newV})
+	case newV == nil:
+		return append(ops, &RemoveOperation{Path: path})
+	default:
+		return append(ops, &ReplaceOperation{Path: path, Value: newV})
+	}
+}
+
+func appendMapDiff(ops []PatchOperation, path string, oldV, newV map[string]interface{}) []PatchOperation {
+	for _, k := range sortedKeys(oldV) {
+		if _, ok := newV[k]; !ok {
+			ops = append(ops, &RemoveOperation{Path: path + "/" + escapeJsonPointer(k)})
+		}
+	}
+
+	for _, k := range sortedKeys(newV) {
+		subPath := path + "/" + escapeJsonPointer(k)
+		if o, ok := oldV[k]; ok {
+			ops = appendDiff(ops, subPath, o, newV[k])
+			continue
+		}
+		ops = append(ops, &AddOperation{Path: subPath, Value: newV[k]})
+	}
+
+	return ops
+}
+
+func appendListDiff(ops []PatchOperation, path string, oldV, newV []interface{}) []PatchOperation {
+	for i := 0; i < len(oldV) && i < len(newV); i++ {
+		ops = appendDiff(ops, path+"/"+strconv.Itoa(i), oldV[i], newV[i])
+	}
+	for i := len(oldV); i < len(newV); i++ {
+		ops = append(ops, &AddOperation{Path: path + "/" + strconv.Itoa(i), Value: newV[i]})
+	}
+	// Remove from the end, so that the indexes of the remaining items don't change.
+	for i := len(oldV) - 1; i >= len(newV); i-- {
+		ops = append(ops, &RemoveOperation{Path: path + "/" + strconv.Itoa(i)})
+	}
+
+	return ops
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func toJSONValue(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var result interface{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,153 @@
+package patch
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	testCases := []struct {
+		Path        string
+		Old         interface{}
+		New         interface{}
+		ExpectedOps PatchOperations
+	}{
+		{
+			Path: "/spec",
+			Old: map[string]interface{}{
+				"description": "old",
+				"params": []interface{}{
+					map[string]interface{}{"name": "one", "type": "string"},
+				},
+			},
+			New: map[string]interface{}{
+				"description": "old",
+				"params": []interface{}{
+					map[string]interface{}{"name": "one", "type": "array"},
+				},
+			},
+			ExpectedOps: []PatchOperation{
+				&ReplaceOperation{
+					Path:  "/spec/params/0/type",
+					Value: "array",
+				},
+			},
+		},
+		{
+			Path: "/spec/",
+			Old: map[string]interface{}{
+				"description": "old",
+				"displayName": "name",
+			},
+			New: map[string]interface{}{
+				"description": "new",
+				"steps":       []interface{}{"one"},
+			},
+			ExpectedOps: []PatchOperation{
+				&ReplaceOperation{
+					Path:  "/spec/description",
+					Value: "new",
+				},
+				&RemoveOperation{Path: "/spec/displayName"},
+				&AddOperation{
+					Path:  "/spec/steps",
+					Value: []interface{}{"one"},
+				},
+			},
+		},
+		{
+			Path: "/spec/steps",
+			Old:  []interface{}{"one", "two", "three"},
+			New:  []interface{}{"one"},
+			ExpectedOps: []PatchOperation{
+				&RemoveOperation{Path: "/spec/steps/2"},
+				&RemoveOperation{Path: "/spec/steps/1"},
+			},
+		},
+		{
+			Path: "/spec/steps",
+			Old:  []interface{}{"one"},
+			New:  []interface{}{"zero", "one"},
+			ExpectedOps: []PatchOperation{
+				&ReplaceOperation{
+					Path:  "/spec/steps/0",
+					Value: "zero",
+				},
+				&AddOperation{
+					Path:  "/spec/steps/1",
+					Value: "one",
+				},
+			},
+		},
+		{
+			Path: "/spec",
+			Old: map[string]interface{}{
+				"workspaces": []interface{}{"one"},
+			},
+			New: map[string]interface{}{
+				"workspaces": map[string]interface{}{"name": "one"},
+			},
+			ExpectedOps: []PatchOperation{
+				&ReplaceOperation{
+					Path:  "/spec/workspaces",
+					Value: map[string]interface{}{"name": "one"},
+				},
+			},
+		},
+		{
+			Path: "/spec",
+			Old: map[string]interface{}{
+				"a/b": map[string]interface{}{"c~d": "1"},
+			},
+			New: map[string]interface{}{
+				"a/b": map[string]interface{}{"c~d": "2"},
+			},
+			ExpectedOps: []PatchOperation{
+				&ReplaceOperation{
+					Path:  "/spec/a~1b/c~0d",
+					Value: "2",
+				},
+			},
+		},
+		{
+			Path:        "/spec",
+			Old:         map[string]interface{}{"description": "same"},
+			New:         map[string]interface{}{"description": "same"},
+			ExpectedOps: []PatchOperation{},
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			ops := Diff(tc.Path, tc.Old, tc.New)
+			if !tc.ExpectedOps.Equal(ops) {
+				t.Fatalf("Operations don't match.\nExpected: %v\nGiven:    %v\n", tc.ExpectedOps, ops)
+			}
+		})
+	}
+}
+
+func TestDiffObjects(t *testing.T) {
+	type step struct {
+		Name  string `json:"name"`
+		Image string `json:"image,omitempty"`
+	}
+	type spec struct {
+		Steps []step `json:"steps"`
+	}
+
+	ops, err := DiffObjects("/spec", spec{Steps: []step{{Name: "build"}}}, spec{Steps: []step{{Name: "build", Image: "golang"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := PatchOperations{
+		&AddOperation{
+			Path:  "/spec/steps/0/image",
+			Value: "golang",
+		},
+	}
+	if !expected.Equal(ops) {
+		t.Fatalf("Operations don't match.\nExpected: %v\nGiven:    %v\n", expected, ops)
+	}
+}
@@ -152,3 +152,24 @@ func (o *RemoveOperation) String() string {
 	b, _ := o.MarshalJSON()
 	return string(b)
 }
+
+// TestOperation makes the whole patch fail when the value at Path is not Value.
+type TestOperation struct {
+	Path  string      `json:"path"`
+	Value interface{} `json:"value"`
+	Op    string      `json:"op"`
+}
+
+func (o *TestOperation) GetPath() string {
+	return o.Path
+}
+
+func (o *TestOperation) MarshalJSON() ([]byte, error) {
+	o.Op = "test"
+	return json.Marshal(*o)
+}
+
+func (o *TestOperation) String() string {
+	b, _ := o.MarshalJSON()
+	return string(b)
+}
@@ -0,0 +1,27 @@
+package utils
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ForceNewFields returns a copy of fields where every configurable field, including the ones nested
+// in blocks, is ForceNew. Setting ForceNew on a block only replaces the resource when the number of
+// its elements changes, not when a nested field changes.
+func ForceNewFields(fields map[string]*schema.Schema) map[string]*schema.Schema {
+	result := make(map[string]*schema.Schema, len(fields))
+
+	for k, v := range fields {
+		s := *v
+		if s.Optional || s.Required {
+			s.ForceNew = true
+		}
+		if r, ok := s.Elem.(*schema.Resource); ok {
+			elem := *r
+			elem.Schema = ForceNewFields(r.Schema)
+			s.Elem = &elem
+		}
+		result[k] = &s
+	}
+
+	return result
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -310,3 +311,11 @@ func StringIsIntInRange(min int, max int) schema.SchemaValidateFunc {
 		return warnings, errors
 	}
 }
+
+func ValidateDuration(value interface{}, key string) (ws []string, es []error) {
+	v := value.(string)
+	if _, err := time.ParseDuration(v); err != nil {
+		es = append(es, fmt.Errorf("%s (%q) must be a valid duration, e.g. \"1h30m\": %s", key, v, err))
+	}
+	return
+}
@@ -0,0 +1,142 @@
+package tekton
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/rh01/terraform-provider-tekton/tekton/client"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// waitPollInterval is the delay before reading the object again when it can't be watched, it is
+// shortened by the tests.
+var waitPollInterval = 5 * time.Second
+
+// objectCondition reports whether the object waited for reached the expected state. obj is nil when
+// the object doesn't exist, and deleted is then true when the object existed while waiting for it,
+// as opposed to not being created yet.
+type objectCondition func(obj runtime.Object, deleted bool) (bool, error)
+
+// waitForObject waits until condition holds for the object name of kind and returns the last object
+// it read, nil when it doesn't exist. The object is read once, then watched from its resourceVersion.
+// When the watch can't be established or breaks, the object is read again and watched anew, every
+// waitPollInterval at most, so the wait degrades to polling.
+func waitForObject(ctx context.Context, cli client.Client, kind *client.Kind, namespace, name string, timeout time.Duration, condition objectCondition) (runtime.Object, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	description := fmt.Sprintf("tekton %s %s", strings.ToLower(kind.Kind), name)
+	// seen is true once the object was read, it is deleted when it is not found anymore.
+	seen := false
+	for {
+		obj, resourceVersion, err := getObject(ctx, cli, kind, namespace, name)
+		if err != nil {
+			return nil, waitError(ctx, description, timeout, err)
+		}
+		done, err := condition(obj, obj == nil && seen)
+		if err != nil {
+			return nil, waitError(ctx, description, timeout, err)
+		}
+		if done {
+			return obj, nil
+		}
+		seen = seen || obj != nil
+
+		w, err := cli.Watch(ctx, kind, namespace, name, resourceVersion)
+		if err != nil {
+			log.Printf("[DEBUG] Polling %s, the watch failed: %s", description, err)
+		} else {
+			obj, done, err := watchObject(ctx, w, description, condition)
+			w.Stop()
+			if err != nil {
+				return nil, waitError(ctx, description, timeout, err)
+			}
+			if done {
+				return obj, nil
+			}
+			log.Printf("[DEBUG] The watch of %s ended, reading it again", description)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, waitError(ctx, description, timeout, ctx.Err())
+		case <-time.After(waitPollInterval):
+		}
+	}
+}
+
+// waitForDeletion waits until the object name of kind doesn't exist anymore.
+func waitForDeletion(ctx context.Context, cli client.Client, kind *client.Kind, namespace, name string, timeout time.Duration) error {
+	_, err := waitForObject(ctx, cli, kind, namespace, name, timeout, func(obj runtime.Object, deleted bool) (bool, error) {
+		if obj == nil {
+			return true, nil
+		}
+		log.Printf("[DEBUG] tekton %s %s is being deleted", strings.ToLower(kind.Kind), name)
+		return false, nil
+	})
+	return err
+}
+
+// getObject reads the object name of kind and returns it with its resourceVersion, or nil and an
+// empty resourceVersion when it doesn't exist.
+func getObject(ctx context.Context, cli client.Client, kind *client.Kind, namespace, name string) (runtime.Object, string, error) {
+	obj := kind.New()
+	if err := cli.Get(ctx, kind, namespace, name, obj); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, "", nil
+		}
+		return nil, "", err
+	}
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return nil, "", err
+	}
+	return obj, accessor.GetResourceVersion(), nil
+}
+
+// watchObject checks condition on the objects of the events of w until it holds or the watch ends.
+func watchObject(ctx context.Context, w watch.Interface, description string, condition objectCondition) (runtime.Object, bool, error) {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, false, ctx.Err()
+		case event, ok := <-w.ResultChan():
+			if !ok {
+				return nil, false, nil
+			}
+
+			var obj runtime.Object
+			deleted := false
+			switch event.Type {
+			case watch.Added, watch.Modified:
+				obj = event.Object
+			case watch.Deleted:
+				deleted = true
+			case watch.Error:
+				// e.g. 410 Gone when the resourceVersion is too old, the object is read again.
+				log.Printf("[DEBUG] The watch of %s failed: %s", description, errors.FromObject(event.Object))
+				return nil, false, nil
+			default:
+				continue
+			}
+
+			done, err := condition(obj, deleted)
+			if err != nil || done {
+				return obj, done, err
+			}
+		}
+	}
+}
+
+func waitError(ctx context.Context, description string, timeout time.Duration, err error) error {
+	if ctx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("waiting for %s: timeout while waiting for state to become ready (timeout: %s)", description, timeout)
+	}
+	return fmt.Errorf("waiting for %s: %s", description, err)
+}
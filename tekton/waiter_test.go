@@ -0,0 +1,414 @@
+package tekton
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/rh01/terraform-provider-tekton/tekton/client"
+	"github.com/rh01/terraform-provider-tekton/tekton/client/mock"
+	tektonapiv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"knative.dev/pkg/apis"
+)
+
+// testTaskRun returns the taskrun waited for by the tests, in state at resourceVersion.
+func testTaskRun(resourceVersion, state string) *tektonapiv1.TaskRun {
+	return &tektonapiv1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "build",
+			Namespace:       "default",
+			ResourceVersion: resourceVersion,
+			Labels:          map[string]string{"state": state},
+		},
+	}
+}
+
+// testTaskRunReady holds when the taskrun is in the ready state.
+func testTaskRunReady(obj runtime.Object, deleted bool) (bool, error) {
+	tr, ok := obj.(*tektonapiv1.TaskRun)
+	return ok && tr.Labels["state"] == "ready", nil
+}
+
+// testWatch is the result of a call to Watch: the events sent by the watch before it is stopped, or
+// an error.
+type testWatch struct {
+	ResourceVersion string
+	Events          []watch.Event
+	Stop            bool
+	Err             error
+}
+
+// newTestWaitClient returns a mock client returning gets, a *tektonapiv1.TaskRun or an error, from the
+// successive calls to Get, and watches from the successive calls to Watch.
+func newTestWaitClient(t *testing.T, gets []interface{}, watches []testWatch) *mock.MockClient {
+	ctrl := gomock.NewController(t)
+	cli := mock.NewMockClient(ctrl)
+
+	var getCalls []*gomock.Call
+	for _, result := range gets {
+		result := result
+		getCalls = append(getCalls, cli.EXPECT().Get(gomock.Any(), client.TaskRunKind, "default", "build", gomock.Any()).DoAndReturn(
+			func(ctx context.Context, kind *client.Kind, namespace, name string, obj runtime.Object) error {
+				if err, ok := result.(error); ok {
+					return err
+				}
+				*obj.(*tektonapiv1.TaskRun) = *result.(*tektonapiv1.TaskRun)
+				return nil
+			}))
+	}
+	gomock.InOrder(getCalls...)
+
+	var watchCalls []*gomock.Call
+	for _, result := range watches {
+		result := result
+		watchCalls = append(watchCalls, cli.EXPECT().Watch(gomock.Any(), client.TaskRunKind, "default", "build", result.ResourceVersion).DoAndReturn(
+			func(ctx context.Context, kind *client.Kind, namespace, name, resourceVersion string) (watch.Interface, error) {
+				if result.Err != nil {
+					return nil, result.Err
+				}
+				w := watch.NewFake()
+				go func() {
+					for _, event := range result.Events {
+						w.Action(event.Type, event.Object)
+					}
+					if result.Stop {
+						w.Stop()
+					}
+				}()
+				return w, nil
+			}))
+	}
+	gomock.InOrder(watchCalls...)
+
+	return cli
+}
+
+func TestWaitForObject(t *testing.T) {
+	notFound := errors.NewNotFound(schema.GroupResource{Group: "tekton.dev", Resource: "taskruns"}, "build")
+	gone := &metav1.Status{Status: metav1.StatusFailure, Code: 410, Reason: metav1.StatusReasonGone}
+
+	testCases := []struct {
+		// Gets are the results of the successive calls to Get, a *tektonapiv1.TaskRun or an error.
+		Gets            []interface{}
+		Watches         []testWatch
+		Deletion        bool
+		Timeout         time.Duration
+		ExpectedVersion string
+		ExpectedError   string
+	}{
+		{
+			Gets:            []interface{}{testTaskRun("1", "ready")},
+			ExpectedVersion: "1",
+		},
+		{
+			Gets: []interface{}{testTaskRun("1", "pending")},
+			Watches: []testWatch{{
+				ResourceVersion: "1",
+				Events: []watch.Event{
+					{Type: watch.Added, Object: testTaskRun("1", "pending")},
+					{Type: watch.Modified, Object: testTaskRun("2", "running")},
+					{Type: watch.Modified, Object: testTaskRun("3", "ready")},
+				},
+			}},
+			ExpectedVersion: "3",
+		},
+		{
+			Gets: []interface{}{testTaskRun("1", "running")},
+			Watches: []testWatch{{
+				ResourceVersion: "1",
+				Events: []watch.Event{
+					{Type: watch.Modified, Object: testTaskRun("2", "running")},
+					{Type: watch.Deleted, Object: testTaskRun("2", "running")},
+				},
+			}},
+			Deletion: true,
+		},
+		{
+			Gets:     []interface{}{notFound},
+			Deletion: true,
+		},
+		{
+			Gets: []interface{}{testTaskRun("1", "pending"), testTaskRun("5", "ready")},
+			Watches: []testWatch{{
+				ResourceVersion: "1",
+				Events: []watch.Event{
+					{Type: watch.Error, Object: gone},
+				},
+			}},
+			ExpectedVersion: "5",
+		},
+		{
+			Gets: []interface{}{testTaskRun("1", "pending"), testTaskRun("2", "running"), testTaskRun("3", "ready")},
+			Watches: []testWatch{
+				{
+					ResourceVersion: "1",
+					Events: []watch.Event{
+						{Type: watch.Modified, Object: testTaskRun("2", "running")},
+					},
+					Stop: true,
+				},
+				{
+					ResourceVersion: "2",
+					Stop:            true,
+				},
+			},
+			ExpectedVersion: "3",
+		},
+		{
+			Gets: []interface{}{testTaskRun("1", "pending"), testTaskRun("2", "ready")},
+			Watches: []testWatch{{
+				ResourceVersion: "1",
+				Err:             fmt.Errorf("watch is not allowed"),
+			}},
+			ExpectedVersion: "2",
+		},
+		{
+			Gets: []interface{}{testTaskRun("1", "pending")},
+			Watches: []testWatch{{
+				ResourceVersion: "1",
+				Events: []watch.Event{
+					{Type: watch.Modified, Object: testTaskRun("2", "running")},
+				},
+			}},
+			Timeout:       50 * time.Millisecond,
+			ExpectedError: "waiting for tekton taskrun build: timeout while waiting for state to become ready (timeout: 50ms)",
+		},
+		{
+			Gets:          []interface{}{errors.NewForbidden(schema.GroupResource{Group: "tekton.dev", Resource: "taskruns"}, "build", fmt.Errorf("denied"))},
+			ExpectedError: "waiting for tekton taskrun build: ",
+		},
+	}
+
+	defer func(interval time.Duration) { waitPollInterval = interval }(waitPollInterval)
+	waitPollInterval = time.Millisecond
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			cli := newTestWaitClient(t, tc.Gets, tc.Watches)
+
+			timeout := tc.Timeout
+			if timeout == 0 {
+				timeout = time.Minute
+			}
+			condition := testTaskRunReady
+			if tc.Deletion {
+				condition = func(obj runtime.Object, deleted bool) (bool, error) {
+					return obj == nil, nil
+				}
+			}
+
+			obj, err := waitForObject(context.Background(), cli, client.TaskRunKind, "default", "build", timeout, condition)
+			if tc.ExpectedError != "" {
+				if err == nil || !strings.HasPrefix(err.Error(), tc.ExpectedError) {
+					t.Fatalf("Expected an error starting with %q, given: %v", tc.ExpectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tc.Deletion {
+				if obj != nil {
+					t.Fatalf("Expected no object, given: %#v", obj)
+				}
+				return
+			}
+			tr, ok := obj.(*tektonapiv1.TaskRun)
+			if !ok || tr.ResourceVersion != tc.ExpectedVersion {
+				t.Fatalf("Expected the taskrun at resourceVersion %q, given: %#v", tc.ExpectedVersion, obj)
+			}
+		})
+	}
+}
+
+func TestWatchObject(t *testing.T) {
+	testCases := []struct {
+		Events          []watch.Event
+		Stop            bool
+		Deletion        bool
+		ExpectedDone    bool
+		ExpectedVersion string
+	}{
+		{
+			Events: []watch.Event{
+				{Type: watch.Bookmark, Object: testTaskRun("2", "")},
+				{Type: watch.Added, Object: testTaskRun("3", "ready")},
+			},
+			ExpectedDone:    true,
+			ExpectedVersion: "3",
+		},
+		{
+			Events: []watch.Event{
+				{Type: watch.Modified, Object: testTaskRun("2", "running")},
+				{Type: watch.Modified, Object: testTaskRun("3", "ready")},
+			},
+			ExpectedDone:    true,
+			ExpectedVersion: "3",
+		},
+		{
+			Events: []watch.Event{
+				{Type: watch.Modified, Object: testTaskRun("2", "ready")},
+				{Type: watch.Deleted, Object: testTaskRun("2", "ready")},
+			},
+			Deletion:     true,
+			ExpectedDone: true,
+		},
+		{
+			Events: []watch.Event{
+				{Type: watch.Error, Object: &metav1.Status{Status: metav1.StatusFailure, Code: 410, Reason: metav1.StatusReasonGone}},
+			},
+			ExpectedDone: false,
+		},
+		{
+			Events: []watch.Event{
+				{Type: watch.Modified, Object: testTaskRun("2", "running")},
+			},
+			Stop:         true,
+			ExpectedDone: false,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			w := watch.NewFake()
+			defer w.Stop()
+			events, stop := tc.Events, tc.Stop
+			go func() {
+				for _, event := range events {
+					w.Action(event.Type, event.Object)
+				}
+				if stop {
+					w.Stop()
+				}
+			}()
+
+			condition := testTaskRunReady
+			if tc.Deletion {
+				condition = func(obj runtime.Object, deleted bool) (bool, error) {
+					return obj == nil, nil
+				}
+			}
+
+			obj, done, err := watchObject(context.Background(), w, "tekton taskrun build", condition)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if done != tc.ExpectedDone {
+				t.Fatalf("Expected done to be %t, given: %t", tc.ExpectedDone, done)
+			}
+			if !done {
+				return
+			}
+			if tc.Deletion {
+				if obj != nil {
+					t.Fatalf("Expected no object, given: %#v", obj)
+				}
+				return
+			}
+			if tr, ok := obj.(*tektonapiv1.TaskRun); !ok || tr.ResourceVersion != tc.ExpectedVersion {
+				t.Fatalf("Expected the taskrun at resourceVersion %q, given: %#v", tc.ExpectedVersion, obj)
+			}
+		})
+	}
+
+	// The context ends the watch when no event satisfies the condition.
+	w := watch.NewFake()
+	defer w.Stop()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, _, err := watchObject(ctx, w, "tekton taskrun build", testTaskRunReady); err != context.DeadlineExceeded {
+		t.Fatalf("Expected error %v, given: %v", context.DeadlineExceeded, err)
+	}
+}
+
+// testTaskRunWithSucceeded returns the taskrun at resourceVersion, with its Succeeded condition.
+func testTaskRunWithSucceeded(resourceVersion string, status corev1.ConditionStatus) *tektonapiv1.TaskRun {
+	tr := testTaskRun(resourceVersion, "")
+	tr.Status.SetCondition(&apis.Condition{Type: apis.ConditionSucceeded, Status: status})
+	return tr
+}
+
+func TestWaitForRunState(t *testing.T) {
+	notFound := errors.NewNotFound(schema.GroupResource{Group: "tekton.dev", Resource: "taskruns"}, "build")
+
+	testCases := []struct {
+		Gets            []interface{}
+		Watches         []testWatch
+		ExpectedVersion string
+		ExpectedError   string
+	}{
+		{
+			Gets: []interface{}{testTaskRunWithSucceeded("1", corev1.ConditionUnknown)},
+			Watches: []testWatch{{
+				ResourceVersion: "1",
+				Events: []watch.Event{
+					{Type: watch.Modified, Object: testTaskRunWithSucceeded("2", corev1.ConditionTrue)},
+				},
+			}},
+			ExpectedVersion: "2",
+		},
+		{
+			Gets: []interface{}{notFound},
+			Watches: []testWatch{{
+				ResourceVersion: "",
+				Events: []watch.Event{
+					{Type: watch.Added, Object: testTaskRunWithSucceeded("1", corev1.ConditionUnknown)},
+					{Type: watch.Modified, Object: testTaskRunWithSucceeded("2", corev1.ConditionFalse)},
+				},
+			}},
+			ExpectedVersion: "2",
+		},
+		{
+			Gets: []interface{}{testTaskRunWithSucceeded("1", corev1.ConditionUnknown)},
+			Watches: []testWatch{{
+				ResourceVersion: "1",
+				Events: []watch.Event{
+					{Type: watch.Modified, Object: testTaskRunWithSucceeded("2", corev1.ConditionUnknown)},
+					{Type: watch.Deleted, Object: testTaskRunWithSucceeded("2", corev1.ConditionUnknown)},
+				},
+			}},
+			ExpectedError: "waiting for tekton taskrun build: the run was deleted outside of Terraform",
+		},
+		{
+			Gets: []interface{}{testTaskRunWithSucceeded("1", corev1.ConditionUnknown), notFound},
+			Watches: []testWatch{{
+				ResourceVersion: "1",
+				Stop:            true,
+			}},
+			ExpectedError: "waiting for tekton taskrun build: the run was deleted outside of Terraform",
+		},
+	}
+
+	defer func(interval time.Duration) { waitPollInterval = interval }(waitPollInterval)
+	waitPollInterval = time.Millisecond
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			cli := newTestWaitClient(t, tc.Gets, tc.Watches)
+
+			// The deletion is reported right away, long before the timeout.
+			obj, err := waitForRunCompletion(context.Background(), cli, client.TaskRunKind, "default", "build", time.Hour)
+			if tc.ExpectedError != "" {
+				if err == nil || err.Error() != tc.ExpectedError {
+					t.Fatalf("Expected the error %q, given: %v", tc.ExpectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tr, ok := obj.(*tektonapiv1.TaskRun); !ok || tr.ResourceVersion != tc.ExpectedVersion {
+				t.Fatalf("Expected the taskrun at resourceVersion %q, given: %#v", tc.ExpectedVersion, obj)
+			}
+		})
+	}
+}